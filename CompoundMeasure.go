@@ -0,0 +1,117 @@
+package closest
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// CompoundMeasure is an all-in-one structure for calculating closest points of a compound shape,
+// which is a set of convex hulls like the output of [Decomposition], and a convex hull.
+// Each piece is measured by its own [Measure], so the last directions are kept for every piece.
+// Pieces whose bounding boxes are further than the closest piece found are skipped.
+type CompoundMeasure struct {
+	// In
+	// Compound is the set of the convex pieces of the first shape.
+	Compound [][]*mgl64.Vec3
+	// ConvexHull is the second shape.
+	ConvexHull []*mgl64.Vec3
+
+	// Out
+	// Distance is the minimum distance or the maximum depth among the pieces, like Distance of [Measure].
+	Distance float64
+	// Direction is from the closest piece to ConvexHull.
+	Direction mgl64.Vec3
+	// Points are the closest points on the closest piece and ConvexHull.
+	Points [2]mgl64.Vec3
+	// Ons are the sets of indices of the vertices that make up the simplex that contains the closest point.
+	Ons [2]map[int]struct{}
+	// Piece is the index of the closest piece in Compound. If there is no piece, this is -1.
+	Piece int
+	// MeasuredCount is the number of the pieces measured actually.
+	MeasuredCount int
+
+	measures []Measure
+}
+
+// MeasureDistance measures the distance or the depth between Compound and ConvexHull, and updates the outputs.
+func (compoundMeasure *CompoundMeasure) MeasureDistance() {
+	compoundMeasure.measure(false)
+}
+
+// MeasureNonnegativeDistance measures the distance between Compound and ConvexHull, and updates the outputs.
+func (compoundMeasure *CompoundMeasure) MeasureNonnegativeDistance() {
+	compoundMeasure.measure(true)
+}
+
+func (compoundMeasure *CompoundMeasure) measure(isNonnegative bool) {
+	if len(compoundMeasure.measures) != len(compoundMeasure.Compound) {
+		compoundMeasure.measures = make([]Measure, len(compoundMeasure.Compound))
+	}
+
+	compoundMeasure.Distance = math.Inf(1)
+	compoundMeasure.Direction = mgl64.Vec3{}
+	compoundMeasure.Points = [2]mgl64.Vec3{}
+	compoundMeasure.Ons = [2]map[int]struct{}{
+		{},
+		{},
+	}
+	compoundMeasure.Piece = -1
+	compoundMeasure.MeasuredCount = 0
+
+	min, max := getBounds(compoundMeasure.ConvexHull)
+	lowerBounds := make([]float64, len(compoundMeasure.Compound))
+	order := make([]int, len(compoundMeasure.Compound))
+	for i, convex := range compoundMeasure.Compound {
+		order[i] = i
+		pieceMin, pieceMax := getBounds(convex)
+		gap := mgl64.Vec3{}
+		for j := 0; j < 3; j += 1 {
+			gap[j] = math.Max(0.0, math.Max(pieceMin[j]-max[j], min[j]-pieceMax[j]))
+		}
+		lowerBounds[i] = gap.Len()
+	}
+	sort.SliceStable(order, func(i int, j int) bool {
+		return lowerBounds[order[i]] < lowerBounds[order[j]]
+	})
+
+	for _, i := range order {
+		// Pieces apart from ConvexHull can be neither closer nor deeper.
+		if lowerBounds[i] > math.Max(compoundMeasure.Distance, 0.0) {
+			break
+		}
+		if isNonnegative && compoundMeasure.Distance <= 0.0 {
+			break
+		}
+
+		measure := &compoundMeasure.measures[i]
+		measure.ConvexHulls = [2][]*mgl64.Vec3{
+			compoundMeasure.Compound[i],
+			compoundMeasure.ConvexHull,
+		}
+		if isNonnegative {
+			measure.MeasureNonnegativeDistance()
+		} else {
+			measure.MeasureDistance()
+		}
+		compoundMeasure.MeasuredCount += 1
+
+		if measure.Distance < compoundMeasure.Distance {
+			compoundMeasure.Distance = measure.Distance
+			compoundMeasure.Direction = measure.Direction
+			compoundMeasure.Points = measure.Points
+			for j, on := range measure.Ons {
+				compoundMeasure.Ons[j] = map[int]struct{}{}
+				for index := range on {
+					compoundMeasure.Ons[j][index] = struct{}{}
+				}
+			}
+			compoundMeasure.Piece = i
+		}
+	}
+
+	if compoundMeasure.Piece < 0 {
+		compoundMeasure.Distance = 0.0
+	}
+}
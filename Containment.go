@@ -0,0 +1,123 @@
+package closest
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Containment tests whether points are inside a convex hull.
+// The face planes of the convex hull are calculated once by [Containment.Prepare] with [NewHull],
+// so testing a lot of points is fast. Points near the boundary are decided by [PointMeasure].
+type Containment struct {
+	// In
+	// ConvexHull contains points or not.
+	ConvexHull []*mgl64.Vec3
+	// Tolerance is the distance from ConvexHull within which points are regarded as contained.
+	// If this is zero, a tolerance relative to the size of ConvexHull is used.
+	Tolerance float64
+
+	planes       []plane
+	min          mgl64.Vec3
+	max          mgl64.Vec3
+	tolerance    float64
+	pointMeasure PointMeasure
+	isPrepared   bool
+}
+
+type plane struct {
+	normal mgl64.Vec3
+	offset float64
+}
+
+// Prepare calculates the face planes of ConvexHull.
+// This must be called again after ConvexHull or Tolerance are changed.
+// Contains and ContainsAll call this automatically at the first time.
+func (containment *Containment) Prepare() {
+	containment.planes = containment.planes[:0]
+	containment.pointMeasure = PointMeasure{
+		ConvexHull: containment.ConvexHull,
+	}
+	containment.isPrepared = true
+
+	if len(containment.ConvexHull) == 0 {
+		return
+	}
+
+	containment.min, containment.max = getBounds(containment.ConvexHull)
+
+	containment.tolerance = containment.Tolerance
+	if containment.tolerance == 0.0 {
+		containment.tolerance = 1e-9 * containment.max.Sub(containment.min).Len()
+	}
+
+	hull := NewHull(containment.ConvexHull)
+candidate:
+	for i, face := range hull.Faces {
+		normal := hull.Normals[i]
+		offset := normal.Dot(*hull.Vertices[face[0]])
+		for _, known := range containment.planes {
+			if known.normal.ApproxEqualThreshold(normal, 1e-9) &&
+				math.Abs(known.offset-offset) <= containment.tolerance {
+				continue candidate
+			}
+		}
+		containment.planes = append(containment.planes, plane{
+			normal: normal,
+			offset: offset,
+		})
+	}
+}
+
+// Contains reports whether point is inside ConvexHull.
+func (containment *Containment) Contains(point mgl64.Vec3) bool {
+	if !containment.isPrepared {
+		containment.Prepare()
+	}
+	if len(containment.ConvexHull) == 0 {
+		return false
+	}
+
+	for i := 0; i < 3; i += 1 {
+		if point[i] < containment.min[i]-containment.tolerance || point[i] > containment.max[i]+containment.tolerance {
+			return false
+		}
+	}
+
+	if len(containment.planes) != 0 {
+		maxS := math.Inf(-1)
+		for _, plane := range containment.planes {
+			s := plane.normal.Dot(point) - plane.offset
+			if s > maxS {
+				maxS = s
+			}
+		}
+
+		if maxS < -containment.tolerance {
+			return true
+		}
+		if maxS > containment.tolerance {
+			return false
+		}
+	}
+
+	// Near the boundary or ConvexHull is degenerated.
+	containment.pointMeasure.Point = point
+	containment.pointMeasure.MeasureNonnegativeDistance()
+	return containment.pointMeasure.Distance <= containment.tolerance
+}
+
+// ContainsAll reports whether each of points is inside ConvexHull.
+// The results are stored into results if it has enough capacity.
+func (containment *Containment) ContainsAll(points []mgl64.Vec3, results []bool) []bool {
+	if cap(results) < len(points) {
+		results = make([]bool, len(points))
+	}
+	results = results[:len(points)]
+
+	for i, point := range points {
+		results[i] = containment.Contains(point)
+	}
+
+	return results
+}
@@ -0,0 +1,71 @@
+package closest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestContainmentContainsAll(t *testing.T) {
+	containment := Containment{
+		ConvexHull: newCube(mgl64.Vec3{}, 1.0),
+	}
+	// Internal points must not make extra faces.
+	containment.ConvexHull = append(containment.ConvexHull,
+		&mgl64.Vec3{0.2, 0.3, -0.4},
+		&mgl64.Vec3{1.0, 0.5, 0.5},
+	)
+
+	random := rand.New(rand.NewSource(1))
+	points := make([]mgl64.Vec3, 100000)
+	for i := range points {
+		points[i] = mgl64.Vec3{
+			4.0*random.Float64() - 2.0,
+			4.0*random.Float64() - 2.0,
+			4.0*random.Float64() - 2.0,
+		}
+	}
+
+	start := time.Now()
+	results := containment.ContainsAll(points, nil)
+	t.Log("Time: ", time.Since(start))
+
+	if len(containment.planes) != 6 {
+		t.Error("Wrong face count:", len(containment.planes))
+	}
+	for i, point := range points {
+		correct := math.Abs(point[0]) <= 1.0 && math.Abs(point[1]) <= 1.0 && math.Abs(point[2]) <= 1.0
+		if results[i] != correct {
+			t.Error(point, results[i])
+		}
+	}
+}
+
+func TestContainmentContains_Degeneration(t *testing.T) {
+	containment := Containment{
+		ConvexHull: []*mgl64.Vec3{
+			{0.0, 0.0, 1.0},
+			{2.0, 0.0, 1.0},
+			{2.0, 2.0, 1.0},
+			{0.0, 2.0, 1.0},
+		},
+	}
+
+	testCases := []struct {
+		point   mgl64.Vec3
+		correct bool
+	}{
+		{mgl64.Vec3{1.0, 1.0, 1.0}, true},
+		{mgl64.Vec3{0.5, 1.5, 1.0}, true},
+		{mgl64.Vec3{1.0, 1.0, 1.1}, false},
+		{mgl64.Vec3{3.0, 1.0, 1.0}, false},
+	}
+	for _, testCase := range testCases {
+		if containment.Contains(testCase.point) != testCase.correct {
+			t.Error(testCase.point, !testCase.correct)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package closest
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Decomposition decomposes a closed triangle mesh into convex hulls approximately.
+// The mesh is cut by axis-aligned planes until every piece is convex enough. The convex hull
+// of each piece contains the part of the solid in the piece, so the convex hulls contain the whole solid.
+// The volume of the solid in each piece is estimated by voxels like V-HACD.
+type Decomposition struct {
+	// In
+	// Mesh is decomposed. It must be closed.
+	Mesh *Mesh
+	// Resolution is the number of voxels along the longest side of Mesh. If this is zero, 32 is used.
+	Resolution int
+	// Concavity is the maximum ratio of the volume out of Mesh to the volume of each convex hull.
+	// If this is zero, 0.05 is used.
+	Concavity float64
+	// MaxCount is the maximum number of convex hulls. If this is zero, 32 is used.
+	MaxCount int
+
+	// Out
+	// Hulls are the convex pieces of Mesh.
+	Hulls []*Hull
+}
+
+type voxels struct {
+	min    mgl64.Vec3
+	size   float64
+	counts [3]int
+	sums   []int // The numbers of the voxels inside the solid before each voxel, for counting voxels in boxes.
+}
+
+type piece struct {
+	lower     [3]int
+	upper     [3]int
+	hull      *Hull
+	emptiness float64
+	concavity float64
+}
+
+// Decompose decomposes Mesh, and updates Hulls.
+func (decomposition *Decomposition) Decompose() {
+	decomposition.Hulls = nil
+	if decomposition.Mesh == nil || len(decomposition.Mesh.Triangles) == 0 {
+		return
+	}
+
+	resolution := decomposition.Resolution
+	if resolution <= 0 {
+		resolution = 32
+	}
+	maxConcavity := decomposition.Concavity
+	if maxConcavity <= 0.0 {
+		maxConcavity = 0.05
+	}
+	maxCount := decomposition.MaxCount
+	if maxCount <= 0 {
+		maxCount = 32
+	}
+
+	voxels := newVoxels(decomposition.Mesh, resolution)
+	pieces := []*piece{}
+	whole := voxels.newPiece(decomposition.Mesh, [3]int{}, voxels.counts)
+	if whole != nil {
+		pieces = append(pieces, whole)
+	}
+
+	for len(pieces) < maxCount {
+		// Split the most concave piece.
+		worst := -1
+		for i, piece := range pieces {
+			if piece.concavity <= maxConcavity || !piece.isSplittable() {
+				continue
+			}
+			if worst < 0 || piece.concavity > pieces[worst].concavity {
+				worst = i
+			}
+		}
+		if worst < 0 {
+			break
+		}
+
+		children := voxels.split(decomposition.Mesh, pieces[worst])
+		pieces[worst] = pieces[len(pieces)-1]
+		pieces = append(pieces[:len(pieces)-1], children...)
+	}
+
+	for _, piece := range pieces {
+		decomposition.Hulls = append(decomposition.Hulls, piece.hull)
+	}
+}
+
+func newVoxels(mesh *Mesh, resolution int) (theVoxels *voxels) {
+	min, max := mesh.Bounds()
+	extent := max.Sub(min)
+	size := math.Max(extent[0], math.Max(extent[1], extent[2])) / float64(resolution)
+	if size == 0.0 {
+		size = 1.0
+	}
+
+	theVoxels = &voxels{
+		min:  min,
+		size: size,
+	}
+	for i := 0; i < 3; i += 1 {
+		theVoxels.counts[i] = int(math.Max(1.0, math.Ceil(extent[i]/size-1e-9)))
+	}
+	counts := theVoxels.counts
+
+	// Cast rays along the x axis and fill between the crossings with the surface.
+	isInside := make([]bool, counts[0]*counts[1]*counts[2])
+	for k := 0; k < counts[2]; k += 1 {
+		for j := 0; j < counts[1]; j += 1 {
+			// Slightly off the centers not to hit edges of the triangles.
+			y := min[1] + (float64(j)+0.5+1.234567e-6)*size
+			z := min[2] + (float64(k)+0.5+2.345678e-6)*size
+
+			crossings := []float64{}
+			for _, triangle := range mesh.Triangles {
+				a := mesh.Vertices[triangle[0]]
+				b := mesh.Vertices[triangle[1]]
+				c := mesh.Vertices[triangle[2]]
+
+				// The barycentric coordinate in the yz plane
+				d := (b[1]-a[1])*(c[2]-a[2]) - (b[2]-a[2])*(c[1]-a[1])
+				if d == 0.0 {
+					continue
+				}
+				u := ((b[1]-y)*(c[2]-z) - (b[2]-z)*(c[1]-y)) / d
+				v := ((c[1]-y)*(a[2]-z) - (c[2]-z)*(a[1]-y)) / d
+				w := 1.0 - u - v
+				if u < 0.0 || v < 0.0 || w < 0.0 {
+					continue
+				}
+				crossings = append(crossings, u*a[0]+v*b[0]+w*c[0])
+			}
+			sort.Float64s(crossings)
+
+			for n := 0; n+1 < len(crossings); n += 2 {
+				for i := 0; i < counts[0]; i += 1 {
+					x := min[0] + (float64(i)+0.5)*size
+					if crossings[n] <= x && x < crossings[n+1] {
+						isInside[i+counts[0]*(j+counts[1]*k)] = true
+					}
+				}
+			}
+		}
+	}
+
+	// The summed volume table
+	theVoxels.sums = make([]int, (counts[0]+1)*(counts[1]+1)*(counts[2]+1))
+	for k := 1; k <= counts[2]; k += 1 {
+		for j := 1; j <= counts[1]; j += 1 {
+			for i := 1; i <= counts[0]; i += 1 {
+				inside := 0
+				if isInside[(i-1)+counts[0]*((j-1)+counts[1]*(k-1))] {
+					inside = 1
+				}
+				theVoxels.sums[theVoxels.sumIndex(i, j, k)] = inside +
+					theVoxels.sums[theVoxels.sumIndex(i-1, j, k)] +
+					theVoxels.sums[theVoxels.sumIndex(i, j-1, k)] +
+					theVoxels.sums[theVoxels.sumIndex(i, j, k-1)] -
+					theVoxels.sums[theVoxels.sumIndex(i-1, j-1, k)] -
+					theVoxels.sums[theVoxels.sumIndex(i-1, j, k-1)] -
+					theVoxels.sums[theVoxels.sumIndex(i, j-1, k-1)] +
+					theVoxels.sums[theVoxels.sumIndex(i-1, j-1, k-1)]
+			}
+		}
+	}
+
+	return
+}
+
+func (voxels *voxels) sumIndex(i, j, k int) int {
+	return i + (voxels.counts[0]+1)*(j+(voxels.counts[1]+1)*k)
+}
+
+// count returns the number of the voxels inside the solid in the box from lower to upper.
+func (voxels *voxels) count(lower, upper [3]int) int {
+	at := func(i, j, k int) int {
+		return voxels.sums[voxels.sumIndex(i, j, k)]
+	}
+
+	return at(upper[0], upper[1], upper[2]) -
+		at(lower[0], upper[1], upper[2]) -
+		at(upper[0], lower[1], upper[2]) -
+		at(upper[0], upper[1], lower[2]) +
+		at(lower[0], lower[1], upper[2]) +
+		at(lower[0], upper[1], lower[2]) +
+		at(upper[0], lower[1], lower[2]) -
+		at(lower[0], lower[1], lower[2])
+}
+
+// newPiece returns the piece of mesh in the box from lower to upper, or nil if the box has no surface.
+func (voxels *voxels) newPiece(mesh *Mesh, lower, upper [3]int) *piece {
+	min, max := mgl64.Vec3{}, mgl64.Vec3{}
+	for i := 0; i < 3; i += 1 {
+		min[i] = voxels.min[i] + float64(lower[i])*voxels.size
+		max[i] = voxels.min[i] + float64(upper[i])*voxels.size
+		// The outermost voxels cover the whole mesh.
+		if lower[i] == 0 {
+			min[i] = math.Inf(-1)
+		}
+		if upper[i] == voxels.counts[i] {
+			max[i] = math.Inf(1)
+		}
+	}
+
+	// The convex hull of the surface in the box is the convex hull of the solid in the box.
+	points := []*mgl64.Vec3{}
+	tolerance := 1e-9 * voxels.size
+triangle:
+	for _, triangle := range mesh.Triangles {
+		polygon := []mgl64.Vec3{
+			*mesh.Vertices[triangle[0]],
+			*mesh.Vertices[triangle[1]],
+			*mesh.Vertices[triangle[2]],
+		}
+
+		// A triangle on the boundary belongs to the box only if the solid behind it is in the box.
+		normal := polygon[1].Sub(polygon[0]).Cross(polygon[2].Sub(polygon[0]))
+		for axis := 0; axis < 3; axis += 1 {
+			for _, boundary := range []float64{min[axis], max[axis]} {
+				isOn := true
+				for _, vertex := range polygon {
+					isOn = isOn && math.Abs(vertex[axis]-boundary) <= tolerance
+				}
+				if !isOn {
+					continue
+				}
+				if (boundary == min[axis] && normal[axis] > 0.0) || (boundary == max[axis] && normal[axis] < 0.0) {
+					continue triangle
+				}
+			}
+		}
+
+		for axis := 0; axis < 3 && len(polygon) != 0; axis += 1 {
+			polygon = clipPolygon(polygon, axis, min[axis], 1.0)
+			polygon = clipPolygon(polygon, axis, max[axis], -1.0)
+		}
+
+		// Triangles touching the box only by their edges are not in the box.
+		area := mgl64.Vec3{}
+		for i := 2; i < len(polygon); i += 1 {
+			area = area.Add(polygon[i-1].Sub(polygon[0]).Cross(polygon[i].Sub(polygon[0])))
+		}
+		if area.Len() <= tolerance*voxels.size {
+			continue
+		}
+		for i := range polygon {
+			points = append(points, &polygon[i])
+		}
+	}
+	if len(points) == 0 {
+		return nil
+	}
+
+	thePiece := &piece{
+		lower: lower,
+		upper: upper,
+		hull:  NewHull(points),
+	}
+	volume := thePiece.hull.Volume()
+	solidVolume := float64(voxels.count(lower, upper)) * voxels.size * voxels.size * voxels.size
+	thePiece.emptiness = math.Max(volume-solidVolume, 0.0)
+	if volume > 0.0 {
+		thePiece.concavity = thePiece.emptiness / volume
+	}
+
+	return thePiece
+}
+
+// clipPolygon keeps the part of polygon where sign*(coordinate[axis] - boundary) >= 0.
+func clipPolygon(polygon []mgl64.Vec3, axis int, boundary float64, sign float64) []mgl64.Vec3 {
+	if math.IsInf(boundary, 0) {
+		return polygon
+	}
+
+	clipped := []mgl64.Vec3{}
+	for i, current := range polygon {
+		next := polygon[(i+1)%len(polygon)]
+		s := sign * (current[axis] - boundary)
+		t := sign * (next[axis] - boundary)
+
+		if s >= 0.0 {
+			clipped = append(clipped, current)
+		}
+		if (s < 0.0 && t > 0.0) || (s > 0.0 && t < 0.0) {
+			crossing := current.Add(next.Sub(current).Mul(s / (s - t)))
+			crossing[axis] = boundary
+			clipped = append(clipped, crossing)
+		}
+	}
+
+	return clipped
+}
+
+func (thePiece *piece) isSplittable() bool {
+	for i := 0; i < 3; i += 1 {
+		if thePiece.upper[i]-thePiece.lower[i] >= 2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// split cuts thePiece by the plane minimizing the volume of the convex hulls out of the solid.
+func (voxels *voxels) split(mesh *Mesh, thePiece *piece) (children []*piece) {
+	const candidateCount = 7
+
+	minEmptiness := math.Inf(1)
+	for axis := 0; axis < 3; axis += 1 {
+		width := thePiece.upper[axis] - thePiece.lower[axis]
+		if width < 2 {
+			continue
+		}
+
+		step := 1
+		if width > candidateCount+1 {
+			step = width / (candidateCount + 1)
+		}
+		for offset := step; offset < width; offset += step {
+			middle := thePiece.lower[axis] + offset
+
+			upper := thePiece.upper
+			upper[axis] = middle
+			lower := thePiece.lower
+			lower[axis] = middle
+
+			candidates := []*piece{}
+			emptiness := 0.0
+			for _, child := range []*piece{
+				voxels.newPiece(mesh, thePiece.lower, upper),
+				voxels.newPiece(mesh, lower, thePiece.upper),
+			} {
+				if child == nil {
+					continue
+				}
+				candidates = append(candidates, child)
+				emptiness += child.emptiness
+			}
+
+			if emptiness < minEmptiness {
+				children = candidates
+				minEmptiness = emptiness
+			}
+		}
+	}
+
+	return
+}
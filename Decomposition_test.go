@@ -0,0 +1,137 @@
+package closest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newLMesh returns the L-shaped prism whose footprint is [0, 2]×[0, 2] minus (1, 2]×(1, 2].
+func newLMesh() *Mesh {
+	footprint := []mgl64.Vec2{
+		{0.0, 0.0},
+		{2.0, 0.0},
+		{2.0, 1.0},
+		{1.0, 1.0},
+		{1.0, 2.0},
+		{0.0, 2.0},
+	}
+
+	mesh := &Mesh{}
+	for _, z := range []float64{0.0, 1.0} {
+		for _, point := range footprint {
+			mesh.Vertices = append(mesh.Vertices, &mgl64.Vec3{point[0], point[1], z})
+		}
+	}
+
+	count := len(footprint)
+	for i := 1; i+1 < count; i += 1 {
+		mesh.Triangles = append(mesh.Triangles,
+			[3]int{0, i + 1, i},
+			[3]int{count, count + i, count + i + 1},
+		)
+	}
+	for i := 0; i < count; i += 1 {
+		j := (i + 1) % count
+		mesh.Triangles = append(mesh.Triangles,
+			[3]int{i, j, count + j},
+			[3]int{i, count + j, count + i},
+		)
+	}
+
+	return mesh
+}
+
+func TestDecompositionDecompose(t *testing.T) {
+	decomposition := Decomposition{
+		Mesh: newLMesh(),
+	}
+	decomposition.Decompose()
+
+	if len(decomposition.Hulls) < 2 {
+		t.Fatal("Not decomposed:", len(decomposition.Hulls))
+	}
+
+	volume := 0.0
+	for _, hull := range decomposition.Hulls {
+		volume += hull.Volume()
+	}
+	difference := cmp.Diff(volume, 3.0, option)
+	if difference != "" {
+		t.Error(difference)
+	}
+
+	// The convex hulls must cover the whole solid.
+	for _, vertex := range decomposition.Mesh.Vertices {
+		minDistance := math.Inf(1)
+		for _, hull := range decomposition.Hulls {
+			minDistance = math.Min(minDistance, hull.ClosestPoint(*vertex).Sub(*vertex).Len())
+		}
+		if minDistance > 1e-12 {
+			t.Error("Not covered:", vertex)
+		}
+	}
+}
+
+func TestDecompositionDecompose_Convex(t *testing.T) {
+	hull := NewHull(newCube(mgl64.Vec3{1.0, 2.0, 3.0}, 1.5))
+	decomposition := Decomposition{
+		Mesh: &Mesh{
+			Vertices:  hull.Vertices,
+			Triangles: hull.Faces,
+		},
+	}
+	decomposition.Decompose()
+
+	if len(decomposition.Hulls) != 1 {
+		t.Fatal("Decomposed:", len(decomposition.Hulls))
+	}
+	difference := cmp.Diff(decomposition.Hulls[0].Volume(), 27.0, option)
+	if difference != "" {
+		t.Error(difference)
+	}
+}
+
+func TestCompoundMeasureMeasureDistance(t *testing.T) {
+	decomposition := Decomposition{
+		Mesh: newLMesh(),
+	}
+	decomposition.Decompose()
+
+	compoundMeasure := CompoundMeasure{}
+	for _, hull := range decomposition.Hulls {
+		compoundMeasure.Compound = append(compoundMeasure.Compound, hull.Vertices)
+	}
+
+	testCases := []struct {
+		convexHull      []*mgl64.Vec3
+		correctDistance float64
+	}{
+		// In the notch of L, where the convex hull of the whole L penetrates.
+		{newCube(mgl64.Vec3{1.6, 1.6, 0.5}, 0.1), 0.5},
+		{newCube(mgl64.Vec3{0.5, 0.5, 3.0}, 0.5), 1.5},
+		{newCube(mgl64.Vec3{0.5, 1.5, 1.1}, 0.2), -0.1},
+	}
+	for _, testCase := range testCases {
+		compoundMeasure.ConvexHull = testCase.convexHull
+		compoundMeasure.MeasureDistance()
+
+		difference := cmp.Diff(compoundMeasure.Distance, testCase.correctDistance, option)
+		if difference != "" {
+			t.Error(difference)
+		}
+		if compoundMeasure.Piece < 0 {
+			t.Error("No piece")
+		}
+		t.Log("Measured Count: ", compoundMeasure.MeasuredCount, "/", len(compoundMeasure.Compound))
+
+		// Ons are not shared with the measure of the piece.
+		compoundMeasure.Ons[0][-1] = struct{}{}
+		_, isShared := compoundMeasure.measures[compoundMeasure.Piece].Ons[0][-1]
+		if isShared {
+			t.Error("Ons are shared with the piece.")
+		}
+	}
+}
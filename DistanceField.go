@@ -0,0 +1,95 @@
+package closest
+
+import (
+	"errors"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// DistanceField samples the signed distance from a convex hull on a regular grid.
+// Neighbouring samples are measured in turn by one [PointMeasure], so each sample starts
+// from the direction of the previous one.
+type DistanceField struct {
+	// In
+	// ConvexHull is measured the distance from.
+	ConvexHull []*mgl64.Vec3
+	// Min and Max are the opposite corners of the sampled region.
+	Min, Max mgl64.Vec3
+	// Counts are the numbers of samples along each axis, which must be positive.
+	// If a count is 1, the samples along the axis are on Min.
+	Counts [3]int
+
+	// Out
+	// Distances are the signed distances. The distance at the grid index (i, j, k) is
+	// Distances[i + Counts[0]*(j + Counts[1]*k)]. Negative distances are inside ConvexHull.
+	Distances []float64
+}
+
+// Sample measures the signed distance at every grid point, and updates Distances.
+// It returns an error without sampling if a count is not positive.
+func (field *DistanceField) Sample() error {
+	for _, count := range field.Counts {
+		if count <= 0 {
+			field.Distances = field.Distances[:0]
+			return errors.New("closest: the counts of the distance field must be positive")
+		}
+	}
+	count := field.Counts[0] * field.Counts[1] * field.Counts[2]
+	if cap(field.Distances) < count {
+		field.Distances = make([]float64, count)
+	}
+	field.Distances = field.Distances[:count]
+
+	pointMeasure := PointMeasure{
+		ConvexHull: field.ConvexHull,
+	}
+
+	// Walk the grid like a snake, so that consecutive samples are always neighbours.
+	for k := 0; k < field.Counts[2]; k += 1 {
+		for jStep := 0; jStep < field.Counts[1]; jStep += 1 {
+			j := jStep
+			if k%2 == 1 {
+				j = field.Counts[1] - 1 - jStep
+			}
+
+			for iStep := 0; iStep < field.Counts[0]; iStep += 1 {
+				i := iStep
+				if (k*field.Counts[1]+jStep)%2 == 1 {
+					i = field.Counts[0] - 1 - iStep
+				}
+
+				pointMeasure.Point = field.Position(i, j, k)
+				pointMeasure.MeasureDistance()
+				field.Distances[field.Index(i, j, k)] = pointMeasure.Distance
+			}
+		}
+	}
+
+	return nil
+}
+
+// Index returns the index of Distances at the grid index (i, j, k).
+func (field *DistanceField) Index(i, j, k int) int {
+	return i + field.Counts[0]*(j+field.Counts[1]*k)
+}
+
+// At returns the signed distance at the grid index (i, j, k).
+func (field *DistanceField) At(i, j, k int) float64 {
+	return field.Distances[field.Index(i, j, k)]
+}
+
+// Position returns the coordinate of the grid index (i, j, k).
+func (field *DistanceField) Position(i, j, k int) (position mgl64.Vec3) {
+	indices := [3]int{i, j, k}
+	for axis := 0; axis < 3; axis += 1 {
+		position[axis] = field.Min[axis]
+		if field.Counts[axis] <= 1 {
+			continue
+		}
+
+		ratio := float64(indices[axis]) / float64(field.Counts[axis]-1)
+		position[axis] += (field.Max[axis] - field.Min[axis]) * ratio
+	}
+
+	return
+}
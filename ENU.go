@@ -0,0 +1,118 @@
+package closest
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// The WGS 84 ellipsoid
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1.0 / 298.257223563
+	wgs84Eccentricity2 = wgs84Flattening * (2.0 - wgs84Flattening)
+)
+
+// ENU is the local east-north-up frame tangent to the WGS 84 ellipsoid at Origin.
+// Geodetic coordinates are not Cartesian, so convert them into the frame before measuring them.
+// The frame is precise around Origin, so choose Origin near the convex hulls.
+type ENU struct {
+	// Origin is the longitude and the latitude in degrees, and the ellipsoidal height in meters.
+	Origin mgl64.Vec3
+}
+
+// NewENU returns the frame at the center of the bounding box of the geodetic convex hulls
+// in the earth-centered earth-fixed coordinates, and at the middle of their heights.
+// Unlike the center of the longitudes, it stays near the convex hulls across the antimeridian.
+func NewENU(convexHulls ...[]*mgl64.Vec3) (enu ENU) {
+	ecefs := []*mgl64.Vec3{}
+	minHeight := math.Inf(1)
+	maxHeight := math.Inf(-1)
+	for _, convex := range convexHulls {
+		for _, vertex := range convex {
+			ecef := GeodeticToECEF(*vertex)
+			ecefs = append(ecefs, &ecef)
+			minHeight = math.Min(minHeight, vertex[2])
+			maxHeight = math.Max(maxHeight, vertex[2])
+		}
+	}
+	if len(ecefs) == 0 {
+		return
+	}
+
+	min, max := getBounds(ecefs)
+	enu.Origin = ECEFToGeodetic(min.Add(max).Mul(0.5))
+	enu.Origin[2] = 0.5 * (minHeight + maxHeight)
+	return
+}
+
+// FromGeodetic converts the longitude, the latitude and the height into the coordinates in meters in the frame.
+func (enu ENU) FromGeodetic(geodetic mgl64.Vec3) mgl64.Vec3 {
+	return enu.getRotation().Mul3x1(GeodeticToECEF(geodetic).Sub(GeodeticToECEF(enu.Origin)))
+}
+
+// FromGeodetics converts the geodetic vertices into the frame. It does not modify geodetics.
+func (enu ENU) FromGeodetics(geodetics []*mgl64.Vec3) []*mgl64.Vec3 {
+	locals := make([]*mgl64.Vec3, len(geodetics))
+	for i, geodetic := range geodetics {
+		local := enu.FromGeodetic(*geodetic)
+		locals[i] = &local
+	}
+	return locals
+}
+
+// ToGeodetic converts the coordinates in meters in the frame into the longitude, the latitude and the height.
+func (enu ENU) ToGeodetic(local mgl64.Vec3) mgl64.Vec3 {
+	return ECEFToGeodetic(GeodeticToECEF(enu.Origin).Add(enu.getRotation().Transpose().Mul3x1(local)))
+}
+
+// getRotation returns the rotation from the earth-centered earth-fixed coordinates into the frame.
+func (enu ENU) getRotation() mgl64.Mat3 {
+	longitude := mgl64.DegToRad(enu.Origin[0])
+	latitude := mgl64.DegToRad(enu.Origin[1])
+	sinLongitude, cosLongitude := math.Sincos(longitude)
+	sinLatitude, cosLatitude := math.Sincos(latitude)
+
+	return mgl64.Mat3FromRows(
+		mgl64.Vec3{-sinLongitude, cosLongitude, 0.0},
+		mgl64.Vec3{-sinLatitude * cosLongitude, -sinLatitude * sinLongitude, cosLatitude},
+		mgl64.Vec3{cosLatitude * cosLongitude, cosLatitude * sinLongitude, sinLatitude},
+	)
+}
+
+// GeodeticToECEF converts the longitude and the latitude in degrees, and the height in meters
+// into the earth-centered earth-fixed coordinates in meters.
+func GeodeticToECEF(geodetic mgl64.Vec3) mgl64.Vec3 {
+	sinLongitude, cosLongitude := math.Sincos(mgl64.DegToRad(geodetic[0]))
+	sinLatitude, cosLatitude := math.Sincos(mgl64.DegToRad(geodetic[1]))
+	radius := wgs84SemiMajorAxis / math.Sqrt(1.0-wgs84Eccentricity2*sinLatitude*sinLatitude)
+
+	return mgl64.Vec3{
+		(radius + geodetic[2]) * cosLatitude * cosLongitude,
+		(radius + geodetic[2]) * cosLatitude * sinLongitude,
+		(radius*(1.0-wgs84Eccentricity2) + geodetic[2]) * sinLatitude,
+	}
+}
+
+// ECEFToGeodetic is the inverse of GeodeticToECEF.
+func ECEFToGeodetic(ecef mgl64.Vec3) mgl64.Vec3 {
+	const iterationCount = 8
+
+	longitude := math.Atan2(ecef[1], ecef[0])
+	p := math.Hypot(ecef[0], ecef[1])
+
+	latitude := math.Atan2(ecef[2], p*(1.0-wgs84Eccentricity2))
+	height := 0.0
+	for i := 0; i < iterationCount; i += 1 {
+		sinLatitude, cosLatitude := math.Sincos(latitude)
+		radius := wgs84SemiMajorAxis / math.Sqrt(1.0-wgs84Eccentricity2*sinLatitude*sinLatitude)
+		height = p*cosLatitude + ecef[2]*sinLatitude - wgs84SemiMajorAxis*wgs84SemiMajorAxis/radius
+		latitude = math.Atan2(ecef[2], p*(1.0-wgs84Eccentricity2*radius/(radius+height)))
+	}
+
+	return mgl64.Vec3{
+		mgl64.RadToDeg(longitude),
+		mgl64.RadToDeg(latitude),
+		height,
+	}
+}
@@ -0,0 +1,60 @@
+package closest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestENU(t *testing.T) {
+	enu := ENU{
+		Origin: mgl64.Vec3{136.0, 36.0, 100.0},
+	}
+
+	for _, geodetic := range []mgl64.Vec3{
+		{136.0, 36.0, 100.0},
+		{136.001, 36.002, 140.0},
+		{135.5, 36.5, -20.0},
+		{-179.9, 89.9, 1e4},
+	} {
+		returned := enu.ToGeodetic(enu.FromGeodetic(geodetic))
+		if math.Abs(returned[0]-geodetic[0]) > 1e-9 || math.Abs(returned[1]-geodetic[1]) > 1e-9 || math.Abs(returned[2]-geodetic[2]) > 1e-6 {
+			t.Error(geodetic, returned)
+		}
+	}
+
+	// About 111 km for a degree of the latitude, and 90 km for the longitude at 36 degrees
+	north := enu.FromGeodetic(mgl64.Vec3{136.0, 37.0, 100.0})
+	if math.Abs(north[1]-110.95e3) > 0.1e3 || math.Abs(north[0]) > 1e-6 {
+		t.Error(north)
+	}
+	east := enu.FromGeodetic(mgl64.Vec3{137.0, 36.0, 100.0})
+	if math.Abs(east[0]-90.2e3) > 0.1e3 || east[2] > 0.0 {
+		t.Error(east)
+	}
+	up := enu.FromGeodetic(mgl64.Vec3{136.0, 36.0, 200.0})
+	if up.Sub(mgl64.Vec3{0.0, 0.0, 100.0}).Len() > 1e-6 {
+		t.Error(up)
+	}
+}
+
+func TestNewENU(t *testing.T) {
+	// Across the antimeridian
+	enu := NewENU(
+		[]*mgl64.Vec3{{179.999, 0.0, 0.0}, {179.999, 0.001, 10.0}},
+		[]*mgl64.Vec3{{-179.999, 0.0, 30.0}},
+	)
+	if math.Abs(math.Abs(enu.Origin[0])-180.0) > 1e-6 || math.Abs(enu.Origin[1]-0.0005) > 1e-6 || enu.Origin[2] != 15.0 {
+		t.Error(enu.Origin)
+	}
+
+	locals := enu.FromGeodetics([]*mgl64.Vec3{{179.999, 0.0, 0.0}, {-179.999, 0.0, 0.0}})
+	if math.Abs(locals[1].Sub(*locals[0]).Len()-222.6) > 0.1 {
+		t.Error(*locals[0], *locals[1])
+	}
+
+	if NewENU() != (ENU{}) {
+		t.Error(NewENU())
+	}
+}
@@ -0,0 +1,407 @@
+package closest
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Hull is a convex hull with its triangular faces.
+// Vertices can be used as ConvexHulls of [Measure] directly.
+type Hull struct {
+	// Vertices are the vertices of the convex hull. They share the points given to [NewHull].
+	Vertices []*mgl64.Vec3
+	// Indices are the indices of Vertices in the points given to [NewHull].
+	Indices []int
+	// Faces are the triangles by the indices of Vertices. They are counterclockwise seen from the outside.
+	// If the convex hull is flat, each triangle appears twice, once for each side.
+	Faces [][3]int
+	// Neighbors[i][j] is the index of the face across the edge from Faces[i][j] to Faces[i][(j+1)%3].
+	Neighbors [][3]int
+	// Normals are the outward unit normals of Faces.
+	Normals []mgl64.Vec3
+}
+
+type hullFace struct {
+	vertices  [3]int
+	neighbors [3]int
+	normal    mgl64.Vec3
+	offset    float64
+	outside   []int
+	isDeleted bool
+}
+
+// NewHull builds the convex hull of points by Quickhull.
+// Points within a tolerance relative to the magnitude of the coordinates are merged into faces.
+// If points are coplanar, the convex hull is a flat polygon.
+// If points are collinear, the convex hull has two Vertices and no Faces.
+func NewHull(points []*mgl64.Vec3) (hull *Hull) {
+	hull = &Hull{}
+	if len(points) == 0 {
+		return
+	}
+
+	scale := 0.0
+	for _, point := range points {
+		for i := 0; i < 3; i += 1 {
+			scale = math.Max(scale, math.Abs(point[i]))
+		}
+	}
+	epsilon := 1e-12 * scale
+
+	// The initial simplex
+	extremes := [6]int{}
+	for i, point := range points {
+		for j := 0; j < 3; j += 1 {
+			if point[j] < points[extremes[2*j]][j] {
+				extremes[2*j] = i
+			}
+			if point[j] > points[extremes[2*j+1]][j] {
+				extremes[2*j+1] = i
+			}
+		}
+	}
+
+	i0, i1 := 0, 0
+	maxLength := -1.0
+	for i := 0; i < len(extremes); i += 1 {
+		for j := i + 1; j < len(extremes); j += 1 {
+			length := points[extremes[j]].Sub(*points[extremes[i]]).LenSqr()
+			if length > maxLength {
+				i0, i1 = extremes[i], extremes[j]
+				maxLength = length
+			}
+		}
+	}
+	if math.Sqrt(maxLength) <= epsilon {
+		hull.addVertex(points, i0)
+		return
+	}
+
+	line := points[i1].Sub(*points[i0]).Normalize()
+	i2 := -1
+	maxDistance := epsilon
+	for i, point := range points {
+		distance := line.Cross(point.Sub(*points[i0])).Len()
+		if distance > maxDistance {
+			i2 = i
+			maxDistance = distance
+		}
+	}
+	if i2 < 0 {
+		hull.addVertex(points, i0)
+		hull.addVertex(points, i1)
+		return
+	}
+
+	normal := points[i1].Sub(*points[i0]).Cross(points[i2].Sub(*points[i0])).Normalize()
+	i3 := -1
+	maxDistance = epsilon
+	for i, point := range points {
+		distance := math.Abs(normal.Dot(point.Sub(*points[i0])))
+		if distance > maxDistance {
+			i3 = i
+			maxDistance = distance
+		}
+	}
+	if i3 < 0 {
+		hull.buildPolygon(points, normal, epsilon)
+		return
+	}
+
+	hull.buildPolyhedron(points, [4]int{i0, i1, i2, i3}, epsilon)
+	return
+}
+
+func (hull *Hull) addVertex(points []*mgl64.Vec3, index int) int {
+	hull.Vertices = append(hull.Vertices, points[index])
+	hull.Indices = append(hull.Indices, index)
+	return len(hull.Vertices) - 1
+}
+
+// buildPolygon builds the flat convex hull by the monotone chain on the plane.
+func (hull *Hull) buildPolygon(points []*mgl64.Vec3, normal mgl64.Vec3, epsilon float64) {
+	u := normal.Cross(mgl64.Vec3{1.0, 0.0, 0.0})
+	if u.LenSqr() < normal.Cross(mgl64.Vec3{0.0, 1.0, 0.0}).LenSqr() {
+		u = normal.Cross(mgl64.Vec3{0.0, 1.0, 0.0})
+	}
+	u = u.Normalize()
+	v := normal.Cross(u)
+
+	order := make([]int, len(points))
+	planar := make([]mgl64.Vec2, len(points))
+	for i, point := range points {
+		order[i] = i
+		planar[i] = mgl64.Vec2{u.Dot(*point), v.Dot(*point)}
+	}
+	sort.Slice(order, func(i int, j int) bool {
+		a, b := planar[order[i]], planar[order[j]]
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] < b[1]
+	})
+
+	// Counterclockwise around normal
+	turns := func(o, a, b mgl64.Vec2) bool {
+		oa := a.Sub(o)
+		ob := b.Sub(o)
+		return oa[0]*ob[1]-oa[1]*ob[0] > epsilon*math.Max(oa.Len(), ob.Len())
+	}
+	polygon := []int{}
+	for pass := 0; pass < 2; pass += 1 {
+		start := len(polygon)
+		for k := 0; k < len(order); k += 1 {
+			index := order[k]
+			if pass == 1 {
+				index = order[len(order)-1-k]
+			}
+			for len(polygon) >= start+2 && !turns(planar[polygon[len(polygon)-2]], planar[polygon[len(polygon)-1]], planar[index]) {
+				polygon = polygon[:len(polygon)-1]
+			}
+			polygon = append(polygon, index)
+		}
+		polygon = polygon[:len(polygon)-1]
+	}
+
+	for _, index := range polygon {
+		hull.addVertex(points, index)
+	}
+	for i := 1; i+1 < len(polygon); i += 1 {
+		hull.Faces = append(hull.Faces, [3]int{0, i, i + 1})
+		hull.Normals = append(hull.Normals, normal)
+	}
+	for i := 1; i+1 < len(polygon); i += 1 {
+		hull.Faces = append(hull.Faces, [3]int{0, i + 1, i})
+		hull.Normals = append(hull.Normals, normal.Mul(-1.0))
+	}
+	hull.connect()
+}
+
+func (hull *Hull) buildPolyhedron(points []*mgl64.Vec3, simplex [4]int, epsilon float64) {
+	faces := []*hullFace{}
+	newFace := func(a, b, c int) int {
+		normal := points[b].Sub(*points[a]).Cross(points[c].Sub(*points[a])).Normalize()
+		faces = append(faces, &hullFace{
+			vertices: [3]int{a, b, c},
+			normal:   normal,
+			offset:   normal.Dot(*points[a]),
+		})
+		return len(faces) - 1
+	}
+	distance := func(face *hullFace, index int) float64 {
+		return face.normal.Dot(*points[index]) - face.offset
+	}
+
+	a, b, c, d := simplex[0], simplex[1], simplex[2], simplex[3]
+	if points[b].Sub(*points[a]).Cross(points[c].Sub(*points[a])).Dot(points[d].Sub(*points[a])) > 0.0 {
+		b, c = c, b
+	}
+	newFace(a, b, c)
+	newFace(a, d, b)
+	newFace(b, d, c)
+	newFace(c, d, a)
+	connectFaces(faces)
+
+	for i := range points {
+		if i == a || i == b || i == c || i == d {
+			continue
+		}
+		for _, face := range faces {
+			if distance(face, i) > epsilon {
+				face.outside = append(face.outside, i)
+				break
+			}
+		}
+	}
+
+	for i := 0; i < len(faces); i += 1 {
+		face := faces[i]
+		if face.isDeleted || len(face.outside) == 0 {
+			continue
+		}
+
+		eye := face.outside[0]
+		for _, index := range face.outside {
+			if distance(face, index) > distance(face, eye) {
+				eye = index
+			}
+		}
+
+		// The faces visible from eye and the horizon around them
+		visibles := []int{i}
+		face.isDeleted = true
+		horizon := [][3]int{} // The start vertex, the end vertex and the face behind
+		for k := 0; k < len(visibles); k += 1 {
+			visible := faces[visibles[k]]
+			for j := 0; j < 3; j += 1 {
+				neighbor := visible.neighbors[j]
+				if faces[neighbor].isDeleted {
+					continue
+				}
+				if distance(faces[neighbor], eye) > epsilon {
+					faces[neighbor].isDeleted = true
+					visibles = append(visibles, neighbor)
+					continue
+				}
+				horizon = append(horizon, [3]int{visible.vertices[j], visible.vertices[(j+1)%3], neighbor})
+			}
+		}
+
+		starts := map[int]int{}
+		ends := map[int]int{}
+		for _, edge := range horizon {
+			created := newFace(edge[0], edge[1], eye)
+			starts[edge[0]] = created
+			ends[edge[1]] = created
+
+			faces[created].neighbors[0] = edge[2]
+			behind := faces[edge[2]]
+			for j := 0; j < 3; j += 1 {
+				if behind.vertices[j] == edge[1] && behind.vertices[(j+1)%3] == edge[0] {
+					behind.neighbors[j] = created
+				}
+			}
+		}
+		for _, edge := range horizon {
+			created := faces[starts[edge[0]]]
+			created.neighbors[1] = starts[edge[1]]
+			created.neighbors[2] = ends[edge[0]]
+		}
+
+		for _, index := range visibles {
+			for _, outside := range faces[index].outside {
+				if outside == eye {
+					continue
+				}
+				for _, edge := range horizon {
+					created := faces[starts[edge[0]]]
+					if distance(created, outside) > epsilon {
+						created.outside = append(created.outside, outside)
+						break
+					}
+				}
+			}
+			faces[index].outside = nil
+		}
+	}
+
+	remap := map[int]int{}
+	for _, face := range faces {
+		if face.isDeleted {
+			continue
+		}
+
+		indices := [3]int{}
+		for j, index := range face.vertices {
+			newIndex, ok := remap[index]
+			if !ok {
+				newIndex = hull.addVertex(points, index)
+				remap[index] = newIndex
+			}
+			indices[j] = newIndex
+		}
+		hull.Faces = append(hull.Faces, indices)
+		hull.Normals = append(hull.Normals, face.normal)
+	}
+	hull.connect()
+}
+
+// connectFaces sets the neighbors of faces by their shared edges.
+func connectFaces(faces []*hullFace) {
+	edges := map[[2]int]int{}
+	for i, face := range faces {
+		for j := 0; j < 3; j += 1 {
+			edges[[2]int{face.vertices[j], face.vertices[(j+1)%3]}] = i
+		}
+	}
+	for _, face := range faces {
+		for j := 0; j < 3; j += 1 {
+			face.neighbors[j] = edges[[2]int{face.vertices[(j+1)%3], face.vertices[j]}]
+		}
+	}
+}
+
+// connect sets Neighbors by the shared edges of Faces.
+func (hull *Hull) connect() {
+	edges := map[[2]int]int{}
+	for i, face := range hull.Faces {
+		for j := 0; j < 3; j += 1 {
+			edges[[2]int{face[j], face[(j+1)%3]}] = i
+		}
+	}
+
+	hull.Neighbors = make([][3]int, len(hull.Faces))
+	for i, face := range hull.Faces {
+		for j := 0; j < 3; j += 1 {
+			hull.Neighbors[i][j] = edges[[2]int{face[(j+1)%3], face[j]}]
+		}
+	}
+}
+
+// ClosestPoint returns the closest point of the convex hull to point by checking every face.
+// If point is inside the convex hull, or on the flat convex hull, point itself is returned.
+// This is exact but slower than [PointMeasure] for convex hulls with many faces.
+func (hull *Hull) ClosestPoint(point mgl64.Vec3) (closest mgl64.Vec3) {
+	switch len(hull.Vertices) {
+	case 0:
+		return point
+	case 1:
+		return *hull.Vertices[0]
+	case 2:
+		return closestPointOnSegment(point, *hull.Vertices[0], *hull.Vertices[1])
+	}
+
+	// Both sides of a flat convex hull face every point on its plane, even outside the polygon.
+	isInside := !hull.isFlat()
+	for i, face := range hull.Faces {
+		if hull.Normals[i].Dot(point.Sub(*hull.Vertices[face[0]])) > 0.0 {
+			isInside = false
+			break
+		}
+	}
+	if isInside {
+		return point
+	}
+
+	minDistance := math.Inf(1)
+	for _, face := range hull.Faces {
+		candidate := closestPointOnTriangle(point, *hull.Vertices[face[0]], *hull.Vertices[face[1]], *hull.Vertices[face[2]])
+		distance := candidate.Sub(point).LenSqr()
+		if distance < minDistance {
+			closest = candidate
+			minDistance = distance
+		}
+	}
+
+	return
+}
+
+// isFlat returns whether the faces are the triangles of a polygon on both sides.
+// Unlike the faces of a polyhedron, all of their normals are parallel.
+func (hull *Hull) isFlat() bool {
+	for _, normal := range hull.Normals {
+		if normal.Cross(hull.Normals[0]).Len() > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+// Volume returns the volume of the convex hull.
+func (hull *Hull) Volume() (volume float64) {
+	if len(hull.Vertices) == 0 {
+		return
+	}
+
+	origin := *hull.Vertices[0]
+	for _, face := range hull.Faces {
+		a := hull.Vertices[face[0]].Sub(origin)
+		b := hull.Vertices[face[1]].Sub(origin)
+		c := hull.Vertices[face[2]].Sub(origin)
+		volume += a.Dot(b.Cross(c))
+	}
+
+	return volume / 6.0
+}
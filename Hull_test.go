@@ -0,0 +1,167 @@
+package closest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewHull(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	points := []*mgl64.Vec3{}
+	for i := 0; i < 1000; i += 1 {
+		points = append(points, &mgl64.Vec3{
+			random.NormFloat64(),
+			random.NormFloat64(),
+			random.NormFloat64(),
+		})
+	}
+
+	hull := NewHull(points)
+	testHull(t, hull, points)
+
+	for _, other := range [][]*mgl64.Vec3{
+		newCube(mgl64.Vec3{3.0, 0.0, 0.0}, 1.0),
+		{{-4.0, 1.0, 5.0}},
+	} {
+		measure := Measure{ConvexHulls: [2][]*mgl64.Vec3{points, other}}
+		measure.MeasureNonnegativeDistance()
+		hullMeasure := Measure{ConvexHulls: [2][]*mgl64.Vec3{hull.Vertices, other}}
+		hullMeasure.MeasureNonnegativeDistance()
+
+		difference := cmp.Diff(hullMeasure.Distance, measure.Distance, option)
+		if difference != "" {
+			t.Error(difference)
+		}
+	}
+}
+
+func TestNewHull_Coplanar(t *testing.T) {
+	// A cube whose faces are grids of coplanar points
+	points := []*mgl64.Vec3{}
+	for i := 0; i <= 4; i += 1 {
+		for j := 0; j <= 4; j += 1 {
+			for k := 0; k <= 4; k += 1 {
+				points = append(points, &mgl64.Vec3{float64(i), float64(j), float64(k)})
+			}
+		}
+	}
+
+	hull := NewHull(points)
+	testHull(t, hull, points)
+	if len(hull.Vertices) != 8 || len(hull.Faces) != 12 {
+		t.Error("Wrong cube:", len(hull.Vertices), len(hull.Faces))
+	}
+}
+
+func TestNewHull_Flat(t *testing.T) {
+	points := []*mgl64.Vec3{
+		{0.0, 0.0, 1.0},
+		{1.0, 1.0, 1.0},
+		{2.0, 0.0, 1.0},
+		{2.0, 2.0, 1.0},
+		{1.0, 0.0, 1.0},
+		{0.0, 2.0, 1.0},
+	}
+
+	hull := NewHull(points)
+	testHull(t, hull, points)
+	if len(hull.Vertices) != 4 || len(hull.Faces) != 4 {
+		t.Error("Wrong square:", len(hull.Vertices), len(hull.Faces))
+	}
+	for i, normal := range hull.Normals {
+		if !normal.ApproxEqual(mgl64.Vec3{0.0, 0.0, 1.0}) && !normal.ApproxEqual(mgl64.Vec3{0.0, 0.0, -1.0}) {
+			t.Error("Wrong normal:", i, normal)
+		}
+	}
+}
+
+func TestHull_ClosestPoint_Flat(t *testing.T) {
+	hull := NewHull([]*mgl64.Vec3{
+		{0.0, 0.0, 1.0},
+		{2.0, 0.0, 1.0},
+		{2.0, 2.0, 1.0},
+		{0.0, 2.0, 1.0},
+	})
+	for _, testCase := range []struct {
+		point   mgl64.Vec3
+		closest mgl64.Vec3
+	}{
+		{mgl64.Vec3{3.0, 1.0, 1.0}, mgl64.Vec3{2.0, 1.0, 1.0}},
+		{mgl64.Vec3{-1.0, -1.0, 1.0}, mgl64.Vec3{0.0, 0.0, 1.0}},
+		{mgl64.Vec3{1.0, 1.0, 1.0}, mgl64.Vec3{1.0, 1.0, 1.0}},
+		{mgl64.Vec3{1.0, 0.5, 3.0}, mgl64.Vec3{1.0, 0.5, 1.0}},
+	} {
+		closest := hull.ClosestPoint(testCase.point)
+		if !closest.ApproxEqual(testCase.closest) {
+			t.Error(testCase.point, ": ", closest, " Expected: ", testCase.closest)
+		}
+	}
+}
+
+func TestNewHull_Collinear(t *testing.T) {
+	points := []*mgl64.Vec3{
+		{1.0, 1.0, 1.0},
+		{0.0, 0.0, 0.0},
+		{3.0, 3.0, 3.0},
+		{2.0, 2.0, 2.0},
+	}
+
+	hull := NewHull(points)
+	if len(hull.Faces) != 0 {
+		t.Error("Wrong face count:", len(hull.Faces))
+	}
+	difference := cmp.Diff(hull.Indices, []int{1, 2})
+	if difference != "" {
+		t.Error(difference)
+	}
+
+	hull = NewHull([]*mgl64.Vec3{{1.0, 2.0, 3.0}, {1.0, 2.0, 3.0}})
+	if len(hull.Vertices) != 1 || len(hull.Faces) != 0 {
+		t.Error("Wrong point:", len(hull.Vertices), len(hull.Faces))
+	}
+}
+
+func testHull(t *testing.T, hull *Hull, points []*mgl64.Vec3) {
+	t.Helper()
+
+	for i, face := range hull.Faces {
+		normal := hull.Vertices[face[1]].Sub(*hull.Vertices[face[0]]).Cross(
+			hull.Vertices[face[2]].Sub(*hull.Vertices[face[0]]),
+		)
+		if normal.Dot(hull.Normals[i]) <= 0.0 {
+			t.Error("Not counterclockwise:", i)
+		}
+
+		offset := hull.Normals[i].Dot(*hull.Vertices[face[0]])
+		for _, point := range points {
+			if hull.Normals[i].Dot(*point)-offset > 1e-9 {
+				t.Error("Outside:", i, point)
+			}
+		}
+
+		for j := 0; j < 3; j += 1 {
+			neighbor := hull.Faces[hull.Neighbors[i][j]]
+			isShared := false
+			for k := 0; k < 3; k += 1 {
+				if neighbor[k] == face[(j+1)%3] && neighbor[(k+1)%3] == face[j] {
+					isShared = true
+				}
+			}
+			if !isShared {
+				t.Error("Wrong neighbor:", i, j)
+			}
+		}
+	}
+
+	for i, vertex := range hull.Vertices {
+		if vertex != points[hull.Indices[i]] {
+			t.Error("Wrong index:", i)
+		}
+	}
+	if len(hull.Faces) != 0 && len(hull.Vertices)-len(hull.Faces)*3/2+len(hull.Faces) != 2 {
+		t.Error("Wrong Euler characteristic:", len(hull.Vertices), len(hull.Faces))
+	}
+}
@@ -7,24 +7,47 @@
 // You can reuse [Measure] any number of times. [Measure] stores the last
 // direction from the first convex hull to the second convex hull, so it can calculate
 // the closest points of the convex hulls faster than the first time.
+// If [Measure.WarmStartsSimplex] is set, it also starts from the last simplex.
+// [Measure] is encoded in JSON with the last simplex, so you can log a measurement and replay it.
+// [Measure32] is the same for the convex hulls in float32, such as of mgl32.
 package closest
 
 import (
 	"math"
 
 	"github.com/go-gl/mathgl/mgl64"
-	"github.com/xieyuschen/deepcopy"
 
+	"container/heap"
 	"log"
-	"sort"
 )
 
+// DefaultTolerance is used if Tolerance of [Measure] is zero.
+const DefaultTolerance = 1e-13
+
 // Measure is an all-in-one structure for calculating closest points of two convex hulls.
 type Measure struct {
 	// In
 	// ConvexHulls are measured the distance between them.
 	// The less degenerate the convex hull, the more precise the result.
 	ConvexHulls [2][]*mgl64.Vec3
+	// Tolerance is relative to the magnitude of the coordinates of ConvexHulls.
+	// The convex hulls closer than this are regarded as touching.
+	// If this is zero, DefaultTolerance is used.
+	Tolerance float64
+	// WarmStartsSimplex seeds GJK with the vertices of the last simplex, re-evaluated on ConvexHulls
+	// by their indices, as well as the last Direction. It pays when ConvexHulls move a little between calls.
+	// If the indices are out of ConvexHulls, only Direction is used.
+	WarmStartsSimplex bool
+	// SimplexSolver is the method for the closest point of the simplex in GJK. See [SimplexSolver].
+	SimplexSolver SimplexSolver
+	// Recenters translates the coordinates of ConvexHulls far from the origin near it exactly before measuring,
+	// as SignedVolumes does. It keeps the precision for the coordinates far from the origin.
+	// Points are in the coordinates of ConvexHulls.
+	Recenters bool
+	// KeepsPolytope keeps the final polytope of EPA in Polytope for debugging.
+	KeepsPolytope bool
+	// KeepsHistory keeps the simplices of GJK in History for debugging.
+	KeepsHistory bool
 
 	// Out
 	// Distance. If this is non-negative, this represents well-known distance s, (ds)² = (dx)² + (dy)² + (dz)².
@@ -38,8 +61,27 @@ type Measure struct {
 	Points [2]mgl64.Vec3
 	// Ons are the sets of indices of the vertices that make up the simplex that contains the closest point.
 	Ons [2]map[int]struct{}
+	// IterationCount is the number of the support points GJK calculated in the last call.
+	IterationCount int
+	// SeedCount is the number of the vertices of the last simplex GJK reused in the last call.
+	SeedCount int
+	// Termination is the reason why the last call stopped.
+	Termination Termination
+	// Polytope is the final polytope of EPA in the Minkowski difference, ConvexHulls[1] - ConvexHulls[0],
+	// if KeepsPolytope is set and the last call calculated the depth. Otherwise, this is nil.
+	Polytope *Mesh
+	// History is the simplices of GJK after each iteration in the last call if KeepsHistory is set.
+	// A simplex is the pairs of the indices of the vertices of ConvexHulls[0] and ConvexHulls[1]. Otherwise, this is nil.
+	History [][][2]int
 
 	simplex []*vertex
+	scale   float64
+	center  mgl64.Vec3 // The translation of ConvexHulls if the convex hulls are recentered
+	// ConvexHulls, or the ones translated by -center if the convex hulls are recentered
+	convexHulls [2][]*mgl64.Vec3
+	// The buffers of the recentered convex hulls
+	recentereds        [2][]mgl64.Vec3
+	recenteredPointers [2][]*mgl64.Vec3
 }
 
 // MeasureDistance measures the distance or the depth between each ConvexHulls, and updates Direction, Points and Ons.
@@ -52,16 +94,44 @@ func (measure *Measure) MeasureDistance() {
 				{},
 				{},
 			}
+			measure.Termination = NoVertices
+			measure.Polytope = nil
+			measure.History = nil
 			return
 		}
 	}
 	measure.gjk()
 
+	tolerance := measure.getTolerance()
 	if len(measure.simplex) < 4 {
-		return
+		if measure.Distance > tolerance {
+			return
+		}
+
+		// The origin is on the simplex, but it is not enough to calculate the depth.
+		if !measure.blowUp() {
+			// The Minkowski difference is flat, so the depth is zero.
+			measure.Termination = Touched
+			return
+		}
 	}
 
+	distance := measure.Distance
+	direction := measure.Direction
+	points := measure.Points
+	ons := measure.Ons
+
 	measure.epa()
+	measure.Termination = Expanded
+
+	if -measure.Distance <= tolerance {
+		// Touching
+		measure.Termination = Touched
+		measure.Distance = distance
+		measure.Direction = direction
+		measure.Points = points
+		measure.Ons = ons
+	}
 }
 
 // MeasureNonnegativeDistance measures distance between each ConvexHulls, and updates Direction, Points and Ons.
@@ -74,6 +144,9 @@ func (measure *Measure) MeasureNonnegativeDistance() {
 				{},
 				{},
 			}
+			measure.Termination = NoVertices
+			measure.Polytope = nil
+			measure.History = nil
 			return
 		}
 	}
@@ -82,11 +155,23 @@ func (measure *Measure) MeasureNonnegativeDistance() {
 }
 
 func (measure *Measure) gjk() {
+	measure.center = mgl64.Vec3{}
+	measure.convexHulls = measure.ConvexHulls
+	if measure.Recenters || measure.SimplexSolver == SignedVolumes {
+		measure.recenter()
+	}
+
+	seeds := measure.getSeeds()
 	measure.simplex = measure.simplex[:0]
+	measure.IterationCount = 0
+	measure.SeedCount = 0
+	measure.Polytope = nil
+	measure.History = nil
+	measure.Termination = Touched // If the simplex gets to a tetrahedron
 
 	maxes := [2]mgl64.Vec3{}
-	for i := 0; i < len(measure.ConvexHulls); i += 1 {
-		for _, vertex := range measure.ConvexHulls[i] {
+	for i := 0; i < len(measure.convexHulls); i += 1 {
+		for _, vertex := range measure.convexHulls[i] {
 			for j := 0; j < 3; j += 1 {
 				target := 2.0 * math.Abs(vertex[j])
 
@@ -96,109 +181,339 @@ func (measure *Measure) gjk() {
 			}
 		}
 	}
+	measure.scale = 0.0
+	for i := 0; i < len(maxes); i += 1 {
+		measure.scale += 0.5 * math.Max(maxes[i][0], math.Max(maxes[i][1], maxes[i][2]))
+	}
+	tolerance := measure.getTolerance()
 
-	var lastSymplex interface{}
+	var lastSymplex []*vertex
 	var lastDirection mgl64.Vec3
 	var lastPoints [2]mgl64.Vec3
 
 loop:
 	for len(measure.simplex) < 4 {
-		lastSymplex, _ = deepcopy.Copy(measure.simplex)
+		lastSymplex = copySimplex(measure.simplex)
 		lastDirection = measure.Direction
 		lastPoints = measure.Points
 
-		measure.simplex = append(measure.simplex, newVertex(measure.ConvexHulls, measure.Direction))
-
-		if measure.simplexHasCyclic(len(measure.simplex)-1, 0) {
-			measure.simplex = lastSymplex.([]*vertex)
-			break loop
+		// Seeds are tried before the support points, and skipped instead of stopping GJK.
+		isSeed := len(seeds) != 0
+		var theVertex *vertex
+		if isSeed {
+			theVertex = seeds[0]
+			seeds = seeds[1:]
+		} else {
+			theVertex = newVertex(measure.convexHulls, measure.Direction)
+			measure.IterationCount += 1
+		}
+
+		if len(measure.simplex) != 0 {
+			// The new vertex does not get closer to the origin than the simplex.
+			lengthSquare := measure.Direction.LenSqr()
+			if lengthSquare-measure.Direction.Dot(theVertex.coordinate) <= tolerance*math.Sqrt(lengthSquare) {
+				if isSeed {
+					continue loop
+				}
+				measure.Termination = Converged
+				break loop
+			}
 		}
 
-		if measure.updateSimplex() {
-			measure.simplex = lastSymplex.([]*vertex)
+		measure.simplex = append(measure.simplex, theVertex)
+
+		if measure.simplexHasCyclic(len(measure.simplex)-1, 0) || measure.solveSimplex() {
+			measure.simplex = lastSymplex
+			if isSeed {
+				continue loop
+			}
+			measure.Termination = Stalled
 			break loop
 		}
 
 		measure.updateDirection()
 		measure.updatePoints()
+		// The simplex must get closer to the origin every time, or the rounding errors may make GJK loop forever.
+		isRejected := len(lastSymplex) != 0 && !(measure.Direction.LenSqr() < lastDirection.LenSqr())
 		for i := 0; i < len(measure.Points); i += 1 {
 			for j := 0; j < 3; j += 1 {
-				if !(math.Abs(measure.Points[i][j]) <= maxes[i][j]) { // For the case where points[i][j] == NaN
-					measure.simplex = lastSymplex.([]*vertex)
-					measure.Direction = lastDirection
-					measure.Points = lastPoints
-					break loop
+				if !(math.Abs(measure.Points[i][j]-measure.center[j]) <= maxes[i][j]) { // For the case where points[i][j] == NaN
+					isRejected = true
 				}
 			}
 		}
+		if isRejected {
+			measure.simplex = lastSymplex
+			measure.Direction = lastDirection
+			measure.Points = lastPoints
+			if isSeed {
+				continue loop
+			}
+			measure.Termination = Stalled
+			break loop
+		}
+		if isSeed {
+			measure.SeedCount += 1
+		}
+		if measure.KeepsHistory {
+			indices := make([][2]int, len(measure.simplex))
+			for i, vertex := range measure.simplex {
+				indices[i] = vertex.indices
+			}
+			measure.History = append(measure.History, indices)
+		}
+
+		if measure.Direction.Len() <= tolerance {
+			// The origin is on the simplex.
+			measure.Termination = Touched
+			break loop
+		}
 	}
 
 	measure.updateOns()
 	measure.updateDistance()
 }
 
-func (measure *Measure) epa() {
-	// Distance　descending order
-	faces := []*face{}
-	switch len(measure.simplex) {
-	case 3:
-		newFace := newFace(measure.simplex, [3]int{0, 1, 2})
-		if newFace.getNormal(measure.simplex).Dot(newFace.measure.Direction) < 0.0 {
-			newFace.indices[1], newFace.indices[2] = newFace.indices[2], newFace.indices[1]
+// getSeeds returns the vertices of the last simplex on the current ConvexHulls if WarmStartsSimplex is set.
+func (measure *Measure) getSeeds() (seeds []*vertex) {
+	if !measure.WarmStartsSimplex {
+		return
+	}
+
+	for _, last := range measure.simplex {
+		for i, index := range last.indices {
+			if index < 0 || index >= len(measure.ConvexHulls[i]) {
+				return nil
+			}
 		}
 
-		faces = append(faces, newFace)
-	case 4:
-		for i := 0; i < len(measure.simplex); i += 1 {
-			indices := [3]int{}
-			k := 0
-			for j := 0; j < len(measure.simplex); j += 1 {
-				if i == j {
-					continue
-				}
-				indices[k] = j
-				k += 1
+		seeds = append(seeds, &vertex{
+			indices:    last.indices,
+			coordinate: measure.convexHulls[1][last.indices[1]].Sub(*measure.convexHulls[0][last.indices[0]]),
+		})
+	}
+
+	return
+}
+
+// recenter translates ConvexHulls near the origin into convexHulls.
+// GJK and EPA calculate the support points on the translated vertices, so the coordinates far from the origin
+// do not lose the precision. The buffers are reused between calls.
+func (measure *Measure) recenter() {
+	lower0, upper0 := getBounds(measure.ConvexHulls[0])
+	lower1, upper1 := getBounds(measure.ConvexHulls[1])
+	for i := 0; i < 3; i += 1 {
+		lower := math.Min(lower0[i], lower1[i])
+		upper := math.Max(upper0[i], upper1[i])
+
+		// The coordinates far from the origin are translated by the nearest one.
+		// The translation is exact by Sterbenz lemma, so the Minkowski difference is the same.
+		switch {
+		case lower > 0.0 && upper <= 2.0*lower:
+			measure.center[i] = lower
+		case upper < 0.0 && lower >= 2.0*upper:
+			measure.center[i] = upper
+		}
+	}
+
+	for i, convex := range measure.ConvexHulls {
+		if cap(measure.recentereds[i]) < len(convex) {
+			measure.recentereds[i] = make([]mgl64.Vec3, len(convex))
+			measure.recenteredPointers[i] = make([]*mgl64.Vec3, len(convex))
+		}
+		measure.recentereds[i] = measure.recentereds[i][:len(convex)]
+		measure.recenteredPointers[i] = measure.recenteredPointers[i][:len(convex)]
+
+		for j, vertex := range convex {
+			measure.recentereds[i][j] = vertex.Sub(measure.center)
+			measure.recenteredPointers[i][j] = &measure.recentereds[i][j]
+		}
+		measure.convexHulls[i] = measure.recenteredPointers[i]
+	}
+}
+
+func (measure *Measure) getTolerance() float64 {
+	tolerance := measure.Tolerance
+	if tolerance == 0.0 {
+		tolerance = DefaultTolerance
+	}
+
+	return tolerance * measure.scale
+}
+
+// blowUp expands the simplex containing the origin into a tetrahedron containing the origin.
+// It returns false if the Minkowski difference is too flat to contain a tetrahedron.
+func (measure *Measure) blowUp() bool {
+	tolerance := measure.getTolerance()
+	support := func(direction mgl64.Vec3) *vertex {
+		return newVertex(measure.convexHulls, direction.Mul(-1.0))
+	}
+
+	if len(measure.simplex) == 1 {
+		for _, direction := range []mgl64.Vec3{
+			{1.0, 0.0, 0.0},
+			{-1.0, 0.0, 0.0},
+			{0.0, 1.0, 0.0},
+			{0.0, -1.0, 0.0},
+			{0.0, 0.0, 1.0},
+			{0.0, 0.0, -1.0},
+		} {
+			newVertex := support(direction)
+			if newVertex.coordinate.Sub(measure.simplex[0].coordinate).Len() > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
 			}
-			newFace := newFace(measure.simplex, indices)
-			if newFace.getNormal(measure.simplex).Dot(newFace.measure.Direction) < 0.0 {
-				newFace.indices[1], newFace.indices[2] = newFace.indices[2], newFace.indices[1]
+		}
+		if len(measure.simplex) == 1 {
+			return false
+		}
+	}
+
+	if len(measure.simplex) == 2 {
+		line := normalize(measure.simplex[1].coordinate.Sub(measure.simplex[0].coordinate))
+
+		axis := mgl64.Vec3{}
+		minComponent := math.Inf(1)
+		for i := 0; i < 3; i += 1 {
+			if math.Abs(line[i]) < minComponent {
+				axis = mgl64.Vec3{}
+				axis[i] = 1.0
+				minComponent = math.Abs(line[i])
 			}
+		}
 
-			faces = append(faces, newFace)
+		direction := line.Cross(axis).Normalize()
+		rotation := mgl64.QuatRotate(math.Pi/3.0, line)
+		for i := 0; i < 6; i += 1 {
+			newVertex := support(direction)
+			if line.Cross(newVertex.coordinate.Sub(measure.simplex[0].coordinate)).Len() > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
+			}
+			direction = rotation.Rotate(direction)
+		}
+		if len(measure.simplex) == 2 {
+			return false
 		}
-	default:
-		log.Panic("Must not come here!")
 	}
 
-	sort.Slice(faces, func(i int, j int) bool {
-		return faces[i].measure.Distance > faces[j].measure.Distance
-	})
+	if len(measure.simplex) == 3 {
+		normal := normalize(measure.simplex[1].coordinate.Sub(measure.simplex[0].coordinate).Cross(
+			measure.simplex[2].coordinate.Sub(measure.simplex[0].coordinate),
+		))
 
-findOuterMinDistanceFace:
-	for {
-		newVertex := newVertex(measure.ConvexHulls, faces[len(faces)-1].measure.Direction.Mul(-1))
-		for _, vertex := range measure.simplex {
-			if newVertex.indices == vertex.indices {
-				break findOuterMinDistanceFace
+		for _, direction := range []mgl64.Vec3{normal, normal.Mul(-1.0)} {
+			newVertex := support(direction)
+			if math.Abs(normal.Dot(newVertex.coordinate.Sub(measure.simplex[0].coordinate))) > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
 			}
 		}
+		if len(measure.simplex) == 3 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (measure *Measure) epa() {
+	if len(measure.simplex) != 4 {
+		log.Panic("Must not come here!")
+	}
+	tolerance := measure.getTolerance()
+	// The new vertices closer to the faces than the rounding errors make the faces inverted.
+	epsilon := 0x1p-52 * measure.scale
+
+	// The tetrahedron with the normals away from the opposite vertices
+	a, b, c, d := 0, 1, 2, 3
+	if measure.simplex[b].coordinate.Sub(measure.simplex[a].coordinate).Cross(
+		measure.simplex[c].coordinate.Sub(measure.simplex[a].coordinate),
+	).Dot(measure.simplex[d].coordinate.Sub(measure.simplex[a].coordinate)) > 0.0 {
+		b, c = c, b
+	}
+	faces := faceHeap{
+		newFace(measure.simplex, a, b, c, epsilon),
+		newFace(measure.simplex, a, d, b, epsilon),
+		newFace(measure.simplex, b, d, c, epsilon),
+		newFace(measure.simplex, c, d, a, epsilon),
+	}
+	connectTwins(faces)
+	var polytope []*face // All the faces if KeepsPolytope is set
+	if measure.KeepsPolytope {
+		polytope = append(polytope, faces...)
+	}
+	heap.Init(&faces)
+
+	var closestFace *face
+	for faces.Len() != 0 {
+		closestFace = heap.Pop(&faces).(*face)
+		if closestFace.isDeleted {
+			continue
+		}
+
+		newVertex := newVertex(measure.convexHulls, closestFace.normal.Mul(-1.0))
+		if measure.simplexHasNear(newVertex.coordinate, epsilon) {
+			// The near-duplicate vertices of the convex hulls have different indices.
+			break
+		}
+		if closestFace.normal.Dot(newVertex.coordinate)-closestFace.distance <= epsilon {
+			break
+		}
 
 		measure.simplex = append(measure.simplex, newVertex)
-		faces = measure.reconstruct(faces)
+		createds := measure.reconstruct(closestFace, epsilon)
+		for _, created := range createds {
+			heap.Push(&faces, created)
+		}
+		if measure.KeepsPolytope {
+			polytope = append(polytope, createds...)
+		}
+	}
+
+	// Coplanar faces are as close as the closest face, so choose the one containing the closest point.
+	coordinates := closestFace.getBarycentricCoordinates(measure.simplex)
+	for faces.Len() != 0 && faces[0].distance <= closestFace.distance+tolerance {
+		candidate := heap.Pop(&faces).(*face)
+		if candidate.isDeleted {
+			continue
+		}
+
+		candidateCoordinates := candidate.getBarycentricCoordinates(measure.simplex)
+		if math.Min(candidateCoordinates[0], math.Min(candidateCoordinates[1], candidateCoordinates[2])) >
+			math.Min(coordinates[0], math.Min(coordinates[1], coordinates[2])) {
+			closestFace = candidate
+			coordinates = candidateCoordinates
+		}
+	}
+
+	if measure.KeepsPolytope {
+		measure.Polytope = newPolytope(measure.simplex, polytope)
 	}
 
 	newSimplex := []*vertex{}
-	for _, index := range faces[len(faces)-1].indices {
+	for i, index := range closestFace.getIndices() {
 		newSimplex = append(newSimplex, measure.simplex[index])
+		newSimplex[i].barycentricCoordinate = math.Max(coordinates[i], 0.0)
 	}
 	measure.simplex = newSimplex
-	measure.updateSimplex()
 	measure.updateDirection()
 	measure.updatePoints()
 	measure.updateOns()
-	measure.updateDistance()
 
-	measure.Distance *= -1.0
+	// If the closest face is inverted by the rounding errors, the origin is out of it,
+	// so the distance is positive and it is regarded as touching.
+	measure.Distance = -closestFace.distance
+}
+
+// simplexHasNear reports whether the simplex has a vertex within epsilon from coordinate.
+func (measure *Measure) simplexHasNear(coordinate mgl64.Vec3, epsilon float64) bool {
+	for _, vertex := range measure.simplex {
+		if vertex.coordinate.Sub(coordinate).Len() <= epsilon {
+			return true
+		}
+	}
+	return false
 }
 
 func (measure *Measure) simplexHasCyclic(i int, j int) bool {
@@ -228,6 +543,20 @@ func (measure *Measure) simplexHasCyclic(i int, j int) bool {
 	return false
 }
 
+// solveSimplex reduces the simplex by SimplexSolver.
+func (measure *Measure) solveSimplex() (isDegenerated bool) {
+	switch measure.SimplexSolver {
+	case VoronoiRegions:
+		isDegenerated = measure.updateSimplex()
+	case SignedVolumes:
+		measure.simplex = solveSignedVolumes(measure.simplex)
+	default:
+		log.Panic("Must not come here!")
+	}
+
+	return
+}
+
 func (measure *Measure) updateSimplex() (isDegenerated bool) {
 	switch len(measure.simplex) {
 	case 1:
@@ -430,25 +759,9 @@ func (measure *Measure) updateSimplex() (isDegenerated bool) {
 		}
 		volumeInverse := 1.0 / volume
 		uABCD := c.Cross(d).Dot(b) * volumeInverse
-		if uABCD == 0.0 {
-			isDegenerated = true
-			return
-		}
 		vABCD := c.Cross(a).Dot(d) * volumeInverse
-		if vABCD == 0.0 {
-			isDegenerated = true
-			return
-		}
 		wABCD := d.Cross(a).Dot(b) * volumeInverse
-		if wABCD == 0.0 {
-			isDegenerated = true
-			return
-		}
 		xABCD := b.Cross(a).Dot(c) * volumeInverse
-		if xABCD == 0.0 {
-			isDegenerated = true
-			return
-		}
 
 		if uABCD < 0.0 && uCBD > 0.0 && vCBD > 0.0 && wCBD > 0.0 {
 			// region CBD
@@ -505,11 +818,17 @@ func (measure *Measure) updateDirection() {
 	case 1:
 		measure.Direction = measure.simplex[0].coordinate
 	case 2:
-		difference := measure.simplex[0].coordinate.Sub(measure.simplex[1].coordinate)
+		// The origin is projected from the nearer vertex, so that the coordinates of the farther one do not cancel out.
+		a := measure.simplex[0].coordinate
+		b := measure.simplex[1].coordinate
+		if b.LenSqr() < a.LenSqr() {
+			a, b = b, a
+		}
+		difference := a.Sub(b)
 
-		x := measure.simplex[0].coordinate.Dot(difference) / difference.LenSqr()
+		x := a.Dot(difference) / difference.LenSqr()
 		difference = difference.Mul(x)
-		measure.Direction = measure.simplex[0].coordinate.Sub(difference)
+		measure.Direction = a.Sub(difference)
 	case 3:
 		ba := measure.simplex[0].coordinate.Sub(measure.simplex[1].coordinate)
 		ca := measure.simplex[0].coordinate.Sub(measure.simplex[2].coordinate)
@@ -533,10 +852,10 @@ func (measure *Measure) updatePoints() {
 	}
 	denominator = 1.0 / denominator
 
-	measure.Points = [2]mgl64.Vec3{}
 	for i := 0; i < len(measure.Points); i += 1 {
+		measure.Points[i] = measure.center
 		for _, vertex := range measure.simplex {
-			measure.Points[i] = measure.Points[i].Add(measure.ConvexHulls[i][vertex.indices[i]].Mul(denominator * vertex.barycentricCoordinate))
+			measure.Points[i] = measure.Points[i].Add(measure.convexHulls[i][vertex.indices[i]].Mul(denominator * vertex.barycentricCoordinate))
 		}
 	}
 }
@@ -553,51 +872,46 @@ func (measure *Measure) updateOns() {
 	}
 }
 
-func (measure *Measure) reconstruct(faces []*face) []*face {
-	reconstructEdges := map[[2]int]struct{}{}
-
-	for i := len(faces) - 1; i >= 0; i-- {
-		if faces[i].getNormal(measure.simplex).Dot(
-			measure.simplex[len(measure.simplex)-1].coordinate.Sub(measure.simplex[faces[i].indices[0]].coordinate),
-		) <= 0.0 { // If new simplex is below the face
-			continue
-		}
-
+// reconstruct removes the faces visible from the last vertex of the simplex, starting from visible,
+// and returns the new faces between the horizon and the last vertex.
+func (measure *Measure) reconstruct(visible *face, epsilon float64) (created []*face) {
+	eye := len(measure.simplex) - 1
+	point := measure.simplex[eye].coordinate
+
+	horizon := []*halfEdge{}
+	visible.isDeleted = true
+	visibles := []*face{visible}
+	for i := 0; i < len(visibles); i += 1 {
+		edge := visibles[i].edge
 		for j := 0; j < 3; j += 1 {
-			k := (j + 1) % 3
-			edgeIndices := [2]int{faces[i].indices[j], faces[i].indices[k]}
-			_, ok := reconstructEdges[edgeIndices]
-
-			if ok {
-				delete(reconstructEdges, edgeIndices)
-			} else {
-				reconstructEdges[[2]int{edgeIndices[1], edgeIndices[0]}] = struct{}{}
+			neighbor := edge.twin.face
+			if !neighbor.isDeleted {
+				if neighbor.isVisibleFrom(point) {
+					neighbor.isDeleted = true
+					visibles = append(visibles, neighbor)
+				} else {
+					horizon = append(horizon, edge)
+				}
 			}
+			edge = edge.next
 		}
-
-		faces = append(faces[:i], faces[i+1:]...)
 	}
 
-reconstruct:
-	for edge := range reconstructEdges {
-		newFace := newFace(measure.simplex, [3]int{
-			edge[1],
-			edge[0],
-			len(measure.simplex) - 1,
-		})
-
-		// Append the new face to the faces
-		for i := len(faces) - 1; i >= 0; i-- {
-			if newFace.measure.Distance <= faces[i].measure.Distance {
-				faces = append(faces[:i+1], faces[i:]...)
-				faces[i+1] = newFace
-				continue reconstruct
-			}
-		}
-
-		faces = append(faces[:1], faces[:]...)
-		faces[0] = newFace
+	// The new faces starting from each vertex on the horizon
+	starts := map[int]*face{}
+	for _, edge := range horizon {
+		newFace := newFace(measure.simplex, edge.origin, edge.next.origin, eye, epsilon)
+		newFace.edge.twin = edge.twin
+		edge.twin.twin = newFace.edge
+		starts[edge.origin] = newFace
+		created = append(created, newFace)
+	}
+	for _, newFace := range created {
+		toEye := newFace.edge.next
+		fromEye := starts[toEye.origin].edge.next.next
+		toEye.twin = fromEye
+		fromEye.twin = toEye
 	}
 
-	return faces
+	return
 }
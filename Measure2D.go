@@ -0,0 +1,425 @@
+package closest
+
+import (
+	"log"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Measure2D is an all-in-one structure for calculating closest points of two convex polygons, like [Measure].
+// It is for ground footprints and map layers, which are degenerate in three dimensions.
+// It stores the last direction, so you can reuse it any number of times.
+type Measure2D struct {
+	// In
+	// ConvexHulls are measured the distance between them.
+	ConvexHulls [2][]*mgl64.Vec2
+	// Tolerance is relative to the magnitude of the coordinates of ConvexHulls.
+	// The convex hulls closer than this are regarded as touching.
+	// If this is zero, DefaultTolerance is used.
+	Tolerance float64
+
+	// Out
+	// Distance. If this is non-negative, this represents well-known distance.
+	// If this is negative, this represents depth, which is the smallest distance for solving collisions.
+	Distance float64
+	// Direction is from ConvexHulls[0] to ConvexHulls[1].
+	Direction mgl64.Vec2
+	// Points are the closest points on each convex hulls.
+	Points [2]mgl64.Vec2
+	// Ons are the sets of indices of the vertices that make up the simplex that contains the closest point.
+	// They are the features of the convex hulls, a vertex or an edge.
+	Ons [2]map[int]struct{}
+
+	simplex []*vertex2D
+	scale   float64
+}
+
+type vertex2D struct {
+	indices               [2]int
+	coordinate            mgl64.Vec2
+	barycentricCoordinate float64
+}
+
+func newVertex2D(convexes [2][]*mgl64.Vec2, direction mgl64.Vec2) *vertex2D {
+	index0 := getIndexOfMaxDotWithDirection2D(convexes[0], direction)
+	index1 := getIndexOfMaxDotWithDirection2D(convexes[1], direction.Mul(-1.0))
+
+	return &vertex2D{
+		indices: [2]int{
+			index0,
+			index1,
+		},
+		coordinate: convexes[1][index1].Sub(*convexes[0][index0]), // The dot product with direction is min
+	}
+}
+
+func getIndexOfMaxDotWithDirection2D(convex []*mgl64.Vec2, direction mgl64.Vec2) (furthestIndex int) {
+	maxS := math.Inf(-1.0)
+
+	for i, vertex := range convex {
+		s := vertex.Dot(direction)
+		if s > maxS {
+			furthestIndex = i
+			maxS = s
+		}
+	}
+
+	return
+}
+
+// MeasureDistance measures the distance or the depth between each ConvexHulls, and updates Direction, Points and Ons.
+func (measure *Measure2D) MeasureDistance() {
+	if measure.isEmpty() {
+		return
+	}
+	measure.gjk()
+
+	tolerance := measure.getTolerance()
+	if len(measure.simplex) < 3 {
+		if measure.Distance > tolerance {
+			return
+		}
+
+		// The origin is on the simplex, but it is not enough to calculate the depth.
+		if !measure.blowUp() {
+			// The Minkowski difference is flat, so the depth is zero.
+			return
+		}
+	}
+
+	distance := measure.Distance
+	direction := measure.Direction
+	points := measure.Points
+	ons := measure.Ons
+
+	measure.epa()
+
+	if -measure.Distance <= tolerance {
+		// Touching
+		measure.Distance = distance
+		measure.Direction = direction
+		measure.Points = points
+		measure.Ons = ons
+	}
+}
+
+// MeasureNonnegativeDistance measures distance between each ConvexHulls, and updates Direction, Points and Ons.
+func (measure *Measure2D) MeasureNonnegativeDistance() {
+	if measure.isEmpty() {
+		return
+	}
+
+	measure.gjk()
+}
+
+func (measure *Measure2D) isEmpty() bool {
+	for _, convex := range measure.ConvexHulls {
+		if len(convex) == 0 {
+			measure.Distance = 0.0
+			measure.Points = [2]mgl64.Vec2{}
+			measure.Ons = [2]map[int]struct{}{
+				{},
+				{},
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+func (measure *Measure2D) gjk() {
+	measure.simplex = measure.simplex[:0]
+
+	measure.scale = 0.0
+	for _, convex := range measure.ConvexHulls {
+		maxAbs := 0.0
+		for _, vertex := range convex {
+			maxAbs = math.Max(maxAbs, math.Max(math.Abs(vertex[0]), math.Abs(vertex[1])))
+		}
+		measure.scale += maxAbs
+	}
+	tolerance := measure.getTolerance()
+
+loop:
+	for len(measure.simplex) < 3 {
+		newVertex := newVertex2D(measure.ConvexHulls, measure.Direction)
+		if len(measure.simplex) != 0 {
+			// The new vertex does not get closer to the origin than the simplex.
+			lengthSquare := measure.Direction.LenSqr()
+			if lengthSquare-measure.Direction.Dot(newVertex.coordinate) <= tolerance*math.Sqrt(lengthSquare) {
+				break loop
+			}
+		}
+		for _, vertex := range measure.simplex {
+			if vertex.indices == newVertex.indices {
+				break loop
+			}
+		}
+
+		measure.simplex = append(measure.simplex, newVertex)
+		measure.updateSimplex()
+		measure.updatePoints()
+
+		if measure.Direction.Len() <= tolerance {
+			// The origin is on the simplex.
+			break loop
+		}
+	}
+
+	measure.updateOns()
+	measure.Distance = measure.Direction.Len()
+}
+
+func (measure *Measure2D) getTolerance() float64 {
+	tolerance := measure.Tolerance
+	if tolerance == 0.0 {
+		tolerance = DefaultTolerance
+	}
+
+	return tolerance * measure.scale
+}
+
+// updateSimplex reduces the simplex to the smallest one containing the closest point to the origin,
+// and updates the barycentric coordinates and Direction.
+func (measure *Measure2D) updateSimplex() {
+	switch len(measure.simplex) {
+	case 1:
+		measure.simplex[0].barycentricCoordinate = 1.0
+		measure.Direction = measure.simplex[0].coordinate
+	case 2:
+		measure.simplex, measure.Direction = closestOnSegment2D(measure.simplex[0], measure.simplex[1])
+	case 3:
+		a := measure.simplex[0].coordinate
+		b := measure.simplex[1].coordinate
+		c := measure.simplex[2].coordinate
+
+		area := cross2D(b.Sub(a), c.Sub(a))
+		if area != 0.0 {
+			u := cross2D(b, c) / area
+			v := cross2D(c, a) / area
+			w := cross2D(a, b) / area
+			if u >= 0.0 && v >= 0.0 && w >= 0.0 {
+				// The origin is in the triangle.
+				measure.simplex[0].barycentricCoordinate = u
+				measure.simplex[1].barycentricCoordinate = v
+				measure.simplex[2].barycentricCoordinate = w
+				measure.Direction = mgl64.Vec2{}
+				break
+			}
+		}
+
+		// The closest point is on an edge.
+		closest := 0
+		minLength := math.Inf(1)
+		for i := 0; i < 3; i += 1 {
+			_, direction := closestOnSegment2D(measure.simplex[i], measure.simplex[(i+1)%3])
+			if direction.Len() < minLength {
+				closest = i
+				minLength = direction.Len()
+			}
+		}
+		// The barycentric coordinates are overwritten by the other edges.
+		measure.simplex, measure.Direction = closestOnSegment2D(measure.simplex[closest], measure.simplex[(closest+1)%3])
+	default:
+		log.Panic("Must not come here!")
+	}
+}
+
+// closestOnSegment2D returns the vertices of the segment from a to b making up the closest point to the origin,
+// and the closest point.
+func closestOnSegment2D(a, b *vertex2D) (simplex []*vertex2D, closest mgl64.Vec2) {
+	ab := b.coordinate.Sub(a.coordinate)
+	lengthSquare := ab.LenSqr()
+	t := 0.0
+	if lengthSquare != 0.0 {
+		t = -a.coordinate.Dot(ab) / lengthSquare
+	}
+
+	switch {
+	case t <= 0.0:
+		a.barycentricCoordinate = 1.0
+		return []*vertex2D{a}, a.coordinate
+	case t >= 1.0:
+		b.barycentricCoordinate = 1.0
+		return []*vertex2D{b}, b.coordinate
+	default:
+		a.barycentricCoordinate = 1.0 - t
+		b.barycentricCoordinate = t
+		return []*vertex2D{a, b}, a.coordinate.Add(ab.Mul(t))
+	}
+}
+
+func cross2D(a, b mgl64.Vec2) float64 {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
+// blowUp expands the simplex containing the origin into a triangle containing the origin.
+// It returns false if the Minkowski difference is too flat to contain a triangle.
+func (measure *Measure2D) blowUp() bool {
+	tolerance := measure.getTolerance()
+	support := func(direction mgl64.Vec2) *vertex2D {
+		return newVertex2D(measure.ConvexHulls, direction.Mul(-1.0))
+	}
+
+	if len(measure.simplex) == 1 {
+		for _, direction := range []mgl64.Vec2{
+			{1.0, 0.0},
+			{-1.0, 0.0},
+			{0.0, 1.0},
+			{0.0, -1.0},
+		} {
+			newVertex := support(direction)
+			if newVertex.coordinate.Sub(measure.simplex[0].coordinate).Len() > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
+			}
+		}
+		if len(measure.simplex) == 1 {
+			return false
+		}
+	}
+
+	line := measure.simplex[1].coordinate.Sub(measure.simplex[0].coordinate).Normalize()
+	normal := mgl64.Vec2{-line[1], line[0]}
+	for _, direction := range []mgl64.Vec2{normal, normal.Mul(-1.0)} {
+		newVertex := support(direction)
+		if math.Abs(normal.Dot(newVertex.coordinate.Sub(measure.simplex[0].coordinate))) > tolerance {
+			measure.simplex = append(measure.simplex, newVertex)
+			return true
+		}
+	}
+
+	return false
+}
+
+// epa expands the polygon in the Minkowski difference from the triangle containing the origin.
+// The polygon is kept convex and counterclockwise.
+func (measure *Measure2D) epa() {
+	polygon := measure.simplex
+	if cross2D(
+		polygon[1].coordinate.Sub(polygon[0].coordinate),
+		polygon[2].coordinate.Sub(polygon[0].coordinate),
+	) < 0.0 {
+		polygon[1], polygon[2] = polygon[2], polygon[1]
+	}
+
+	known := map[[2]int]struct{}{}
+	for _, vertex := range polygon {
+		known[vertex.indices] = struct{}{}
+	}
+
+	var closest int
+	var normal mgl64.Vec2
+	var distance float64
+	for {
+		closest, normal, distance = getClosestEdge2D(polygon)
+
+		newVertex := newVertex2D(measure.ConvexHulls, normal.Mul(-1.0))
+		if _, ok := known[newVertex.indices]; ok {
+			break
+		}
+		if normal.Dot(newVertex.coordinate)-distance <= 0.0 {
+			break
+		}
+		known[newVertex.indices] = struct{}{}
+
+		// Insert the new vertex, and remove the vertices which become concave.
+		at := closest + 1
+		polygon = append(polygon[:at], append([]*vertex2D{newVertex}, polygon[at:]...)...)
+		for len(polygon) > 3 {
+			previous := (at + len(polygon) - 1) % len(polygon)
+			beforePrevious := (at + len(polygon) - 2) % len(polygon)
+			if cross2D(
+				polygon[previous].coordinate.Sub(polygon[beforePrevious].coordinate),
+				newVertex.coordinate.Sub(polygon[previous].coordinate),
+			) > 0.0 {
+				break
+			}
+			polygon = append(polygon[:previous], polygon[previous+1:]...)
+			if previous < at {
+				at -= 1
+			}
+		}
+		for len(polygon) > 3 {
+			next := (at + 1) % len(polygon)
+			afterNext := (at + 2) % len(polygon)
+			if cross2D(
+				polygon[next].coordinate.Sub(newVertex.coordinate),
+				polygon[afterNext].coordinate.Sub(polygon[next].coordinate),
+			) > 0.0 {
+				break
+			}
+			polygon = append(polygon[:next], polygon[next+1:]...)
+			if next < at {
+				at -= 1
+			}
+		}
+	}
+
+	// The closest point is the projection of the origin onto the closest edge.
+	a := polygon[closest]
+	b := polygon[(closest+1)%len(polygon)]
+	measure.Direction = normal.Mul(distance)
+	ab := b.coordinate.Sub(a.coordinate)
+	t := measure.Direction.Sub(a.coordinate).Dot(ab) / ab.LenSqr()
+	t = math.Max(0.0, math.Min(1.0, t))
+	a.barycentricCoordinate = 1.0 - t
+	b.barycentricCoordinate = t
+
+	measure.simplex = []*vertex2D{a, b}
+	measure.updatePoints()
+	measure.updateOns()
+	measure.Distance = -distance
+}
+
+// getClosestEdge2D returns the edge of the counterclockwise polygon closest to the origin
+// with its outward unit normal and the distance to its line.
+func getClosestEdge2D(polygon []*vertex2D) (closest int, normal mgl64.Vec2, distance float64) {
+	distance = math.Inf(1)
+	for i := range polygon {
+		edge := polygon[(i+1)%len(polygon)].coordinate.Sub(polygon[i].coordinate)
+		length := edge.Len()
+		if length == 0.0 {
+			continue
+		}
+
+		edgeNormal := mgl64.Vec2{edge[1] / length, -edge[0] / length}
+		edgeDistance := edgeNormal.Dot(polygon[i].coordinate)
+		if edgeDistance < distance {
+			closest = i
+			normal = edgeNormal
+			distance = edgeDistance
+		}
+	}
+
+	return
+}
+
+func (measure *Measure2D) updatePoints() {
+	denominator := 0.0
+	for _, vertex := range measure.simplex {
+		denominator += vertex.barycentricCoordinate
+	}
+	denominator = 1.0 / denominator
+
+	measure.Points = [2]mgl64.Vec2{}
+	for i := 0; i < len(measure.Points); i += 1 {
+		for _, vertex := range measure.simplex {
+			measure.Points[i] = measure.Points[i].Add(measure.ConvexHulls[i][vertex.indices[i]].Mul(denominator * vertex.barycentricCoordinate))
+		}
+	}
+}
+
+func (measure *Measure2D) updateOns() {
+	measure.Ons = [2]map[int]struct{}{
+		{},
+		{},
+	}
+	for i := 0; i < len(measure.Points); i += 1 {
+		for _, vertex := range measure.simplex {
+			measure.Ons[i][vertex.indices[i]] = struct{}{}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package closest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMeasure2DMeasureDistance(t *testing.T) {
+	square := []*mgl64.Vec2{
+		{0.0, 0.0},
+		{2.0, 0.0},
+		{2.0, 2.0},
+		{0.0, 2.0},
+	}
+	for _, testCase := range []struct {
+		convexHull      []*mgl64.Vec2
+		correctDistance float64
+	}{
+		{
+			convexHull: []*mgl64.Vec2{
+				{3.0, 1.0},
+				{4.0, 0.0},
+				{4.0, 2.0},
+			},
+			correctDistance: 1.0,
+		},
+		{
+			convexHull: []*mgl64.Vec2{
+				{2.0, 0.5},
+				{3.0, 0.5},
+				{3.0, 1.5},
+			},
+			correctDistance: 0.0,
+		},
+		{
+			convexHull: []*mgl64.Vec2{
+				{1.5, 0.5},
+				{3.0, 0.5},
+				{3.0, 1.5},
+				{1.5, 1.5},
+			},
+			correctDistance: -0.5,
+		},
+		{
+			convexHull: []*mgl64.Vec2{
+				{1.0, 1.0},
+			},
+			correctDistance: -1.0,
+		},
+	} {
+		measure := Measure2D{
+			ConvexHulls: [2][]*mgl64.Vec2{
+				square,
+				testCase.convexHull,
+			},
+		}
+		measure.MeasureDistance()
+
+		difference := cmp.Diff(measure.Distance, testCase.correctDistance, option)
+		if difference != "" {
+			t.Error(difference)
+		}
+	}
+
+	// The same as TestMeasureNonnegativeDistance_2Dimension
+	measure := Measure2D{
+		ConvexHulls: [2][]*mgl64.Vec2{
+			{
+				{10, 15},
+				{93.76614808098593, 15},
+			},
+			{
+				{26.902334690093994, 7.686383247375488},
+				{30.745525360107422, 7.686383247375488},
+				{30.745525360107422, 11.529574871063232},
+				{26.902334690093994, 11.529574871063232},
+			},
+		},
+	}
+	measure.MeasureNonnegativeDistance()
+	difference := cmp.Diff(measure.Distance, 3.4704251289367676, option)
+	if difference != "" {
+		t.Error(difference)
+	}
+	if len(measure.Ons[0]) != 2 || len(measure.Ons[1]) != 2 {
+		t.Error("Ons: ", measure.Ons)
+	}
+}
+
+func TestMeasure2DMeasureDistanceRandomly(t *testing.T) {
+	measure := Measure2D{}
+	for n := 0; n < 1000; n += 1 {
+		for i := range measure.ConvexHulls {
+			measure.ConvexHulls[i] = make([]*mgl64.Vec2, rand.Intn(6)+3)
+			for j := range measure.ConvexHulls[i] {
+				measure.ConvexHulls[i][j] = &mgl64.Vec2{
+					rand.Float64(),
+					rand.Float64(),
+				}
+			}
+		}
+
+		measure.MeasureDistance()
+
+		correctDistance := bruteForceDistance2D(measure.ConvexHulls)
+		if math.Abs(measure.Distance-correctDistance) > 1e-9 {
+			t.Error(n, measure.Distance, correctDistance)
+		}
+		if measure.Points[1].Sub(measure.Points[0]).Sub(measure.Direction).Len() > 1e-9 {
+			t.Error(n, measure.Points, measure.Direction)
+		}
+	}
+}
+
+// bruteForceDistance2D tries all the pairs of the vertices as edges and separating axes.
+func bruteForceDistance2D(convexHulls [2][]*mgl64.Vec2) float64 {
+	minOverlap := math.Inf(1)
+	minDistance := math.Inf(1)
+	for i, convex := range convexHulls {
+		other := convexHulls[(i+1)%2]
+		for j, a := range convex {
+			for _, b := range convex[j+1:] {
+				edge := b.Sub(*a)
+				if edge.Len() == 0.0 {
+					continue
+				}
+				normal := mgl64.Vec2{-edge[1], edge[0]}.Normalize()
+
+				mins := [2]float64{math.Inf(1), math.Inf(1)}
+				maxes := [2]float64{math.Inf(-1), math.Inf(-1)}
+				for k, convex := range convexHulls {
+					for _, vertex := range convex {
+						mins[k] = math.Min(mins[k], normal.Dot(*vertex))
+						maxes[k] = math.Max(maxes[k], normal.Dot(*vertex))
+					}
+				}
+				minOverlap = math.Min(minOverlap, math.Min(maxes[0]-mins[1], maxes[1]-mins[0]))
+
+				for _, vertex := range other {
+					t := math.Max(0.0, math.Min(1.0, vertex.Sub(*a).Dot(edge)/edge.LenSqr()))
+					minDistance = math.Min(minDistance, a.Add(edge.Mul(t)).Sub(*vertex).Len())
+				}
+			}
+		}
+	}
+
+	if minOverlap < 0.0 {
+		return minDistance
+	}
+	return -minOverlap
+}
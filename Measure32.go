@@ -0,0 +1,655 @@
+package closest
+
+import (
+	"log"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultTolerance32 is used if Tolerance of [Measure32] is zero.
+// It is about ten times the precision of float32, so the convex hulls closer than the rounding errors are regarded as touching.
+const DefaultTolerance32 = 1e-6
+
+// Measure32 is an all-in-one structure for calculating closest points of two convex hulls in float32, like [Measure].
+// It is for the coordinates kept in mgl32, such as on flight controllers, and runs GJK and EPA in float32
+// without converting them. The results are as precise as float32, so use [Measure] if you need more.
+// It stores the last direction, so you can reuse it any number of times.
+type Measure32 struct {
+	// In
+	// ConvexHulls are measured the distance between them.
+	ConvexHulls [2][]*mgl32.Vec3
+	// Tolerance is relative to the magnitude of the coordinates of ConvexHulls.
+	// The convex hulls closer than this are regarded as touching.
+	// If this is zero, DefaultTolerance32 is used.
+	Tolerance float32
+
+	// Out
+	// Distance. If this is non-negative, this represents well-known distance.
+	// If this is negative, this represents depth, which is the smallest distance for solving collisions.
+	Distance float32
+	// Direction is from ConvexHulls[0] to ConvexHulls[1].
+	Direction mgl32.Vec3
+	// Points are the closest points on each convex hulls.
+	Points [2]mgl32.Vec3
+	// Ons are the sets of indices of the vertices that make up the simplex that contains the closest point.
+	Ons [2]map[int]struct{}
+	// Termination is the reason why the last call stopped.
+	Termination Termination
+
+	simplex []*vertex32
+	scale   float32
+}
+
+type vertex32 struct {
+	indices               [2]int
+	coordinate            mgl32.Vec3
+	barycentricCoordinate float32
+}
+
+func newVertex32(convexes [2][]*mgl32.Vec3, direction mgl32.Vec3) *vertex32 {
+	index0 := getIndexOfMaxDotWithDirection32(convexes[0], direction)
+	index1 := getIndexOfMaxDotWithDirection32(convexes[1], direction.Mul(-1.0))
+
+	return &vertex32{
+		indices: [2]int{
+			index0,
+			index1,
+		},
+		coordinate: convexes[1][index1].Sub(*convexes[0][index0]), // The dot product with direction is min
+	}
+}
+
+func getIndexOfMaxDotWithDirection32(convex []*mgl32.Vec3, direction mgl32.Vec3) (furthestIndex int) {
+	maxS := float32(math.Inf(-1.0))
+
+	for i, vertex := range convex {
+		s := vertex.Dot(direction)
+		if s > maxS {
+			furthestIndex = i
+			maxS = s
+		}
+	}
+
+	return
+}
+
+func copySimplex32(simplex []*vertex32) []*vertex32 {
+	copied := make([]*vertex32, len(simplex))
+	for i, vertex := range simplex {
+		newVertex := *vertex
+		copied[i] = &newVertex
+	}
+
+	return copied
+}
+
+// MeasureDistance measures the distance or the depth between each ConvexHulls, and updates Direction, Points and Ons.
+func (measure *Measure32) MeasureDistance() {
+	if measure.isEmpty() {
+		return
+	}
+	measure.gjk()
+
+	tolerance := measure.getTolerance()
+	if len(measure.simplex) < 4 {
+		if measure.Distance > tolerance {
+			return
+		}
+
+		// The origin is on the simplex, but it is not enough to calculate the depth.
+		if !measure.blowUp() {
+			// The Minkowski difference is flat, so the depth is zero.
+			return
+		}
+	}
+
+	distance := measure.Distance
+	direction := measure.Direction
+	points := measure.Points
+	ons := measure.Ons
+	termination := measure.Termination
+
+	measure.epa()
+
+	if -measure.Distance <= tolerance {
+		// Touching
+		measure.Distance = distance
+		measure.Direction = direction
+		measure.Points = points
+		measure.Ons = ons
+		measure.Termination = termination
+	}
+}
+
+// MeasureNonnegativeDistance measures distance between each ConvexHulls, and updates Direction, Points and Ons.
+func (measure *Measure32) MeasureNonnegativeDistance() {
+	if measure.isEmpty() {
+		return
+	}
+
+	measure.gjk()
+}
+
+func (measure *Measure32) isEmpty() bool {
+	for _, convex := range measure.ConvexHulls {
+		if len(convex) == 0 {
+			measure.Distance = 0.0
+			measure.Points = [2]mgl32.Vec3{}
+			measure.Ons = [2]map[int]struct{}{
+				{},
+				{},
+			}
+			measure.Termination = NoVertices
+			return true
+		}
+	}
+
+	return false
+}
+
+func (measure *Measure32) gjk() {
+	measure.simplex = measure.simplex[:0]
+
+	measure.scale = 0.0
+	for _, convex := range measure.ConvexHulls {
+		var maxAbs float32
+		for _, vertex := range convex {
+			for _, component := range vertex {
+				maxAbs = max32(maxAbs, mgl32.Abs(component))
+			}
+		}
+		measure.scale += maxAbs
+	}
+	tolerance := measure.getTolerance()
+
+	measure.Termination = Touched
+loop:
+	for len(measure.simplex) < 4 {
+		newVertex := newVertex32(measure.ConvexHulls, measure.Direction)
+		if len(measure.simplex) != 0 {
+			// The new vertex does not get closer to the origin than the simplex.
+			lengthSquare := measure.Direction.LenSqr()
+			if lengthSquare-measure.Direction.Dot(newVertex.coordinate) <= tolerance*float32(math.Sqrt(float64(lengthSquare))) {
+				measure.Termination = Converged
+				break loop
+			}
+		}
+		for _, vertex := range measure.simplex {
+			if vertex.indices == newVertex.indices {
+				measure.Termination = Converged
+				break loop
+			}
+		}
+
+		lastSimplex := copySimplex32(measure.simplex)
+		lastDirection := measure.Direction
+		measure.simplex = append(measure.simplex, newVertex)
+		if !measure.updateSimplex() ||
+			len(lastSimplex) != 0 && measure.Direction.LenSqr() >= lastDirection.LenSqr() {
+			// The rounding errors of float32 keep the simplex from getting closer to the origin.
+			measure.simplex = lastSimplex
+			measure.Direction = lastDirection
+			measure.Termination = Stalled
+			break loop
+		}
+		measure.updatePoints()
+
+		if measure.Direction.Len() <= tolerance {
+			// The origin is on the simplex.
+			measure.Termination = Touched
+			break loop
+		}
+	}
+
+	measure.updateOns()
+	measure.Distance = measure.Direction.Len()
+}
+
+func (measure *Measure32) getTolerance() float32 {
+	tolerance := measure.Tolerance
+	if tolerance == 0.0 {
+		tolerance = DefaultTolerance32
+	}
+
+	return tolerance * measure.scale
+}
+
+// updateSimplex reduces the simplex to the smallest one containing the closest point to the origin,
+// and updates the barycentric coordinates and Direction.
+// It returns false if the simplex is degenerate.
+func (measure *Measure32) updateSimplex() bool {
+	switch len(measure.simplex) {
+	case 1:
+		measure.simplex[0].barycentricCoordinate = 1.0
+		measure.Direction = measure.simplex[0].coordinate
+	case 2:
+		measure.simplex, measure.Direction = closestOnSegment32(measure.simplex[0], measure.simplex[1])
+	case 3:
+		simplex, closest, ok := closestOnTriangle32(measure.simplex[0], measure.simplex[1], measure.simplex[2])
+		if !ok {
+			return false
+		}
+		measure.simplex, measure.Direction = simplex, closest
+	case 4:
+		return measure.updateTetrahedron()
+	default:
+		log.Panic("Must not come here!")
+	}
+
+	return true
+}
+
+// updateTetrahedron reduces the tetrahedron to its closest face if the origin is out of it.
+// It returns false if the tetrahedron is flat.
+func (measure *Measure32) updateTetrahedron() bool {
+	tolerance := measure.getTolerance()
+	tetrahedron := measure.simplex
+	closestFace := -1
+	minLengthSquare := float32(math.Inf(1))
+	barycentricCoordinates := [4]float32{}
+	for i := 0; i < 4; i += 1 {
+		// The face is the other three vertices, and the opposite one is i.
+		a := tetrahedron[(i+1)%4]
+		b := tetrahedron[(i+2)%4]
+		c := tetrahedron[(i+3)%4]
+		normal := b.coordinate.Sub(a.coordinate).Cross(c.coordinate.Sub(a.coordinate))
+		opposite := normal.Dot(tetrahedron[i].coordinate.Sub(a.coordinate))
+		if mgl32.Abs(opposite) <= tolerance*normal.Len() {
+			// The tetrahedron is flatter than the tolerance, so the signs of the barycentric coordinates are not reliable.
+			return false
+		}
+
+		origin := -normal.Dot(a.coordinate)
+		barycentricCoordinates[i] = origin / opposite
+		if barycentricCoordinates[i] >= 0.0 {
+			continue
+		}
+
+		// The origin is out of this face.
+		_, closest, ok := closestOnTriangle32(a, b, c)
+		if ok && closest.LenSqr() < minLengthSquare {
+			closestFace = i
+			minLengthSquare = closest.LenSqr()
+		}
+	}
+
+	if closestFace == -1 {
+		// The origin is in the tetrahedron.
+		for i, vertex := range tetrahedron {
+			vertex.barycentricCoordinate = barycentricCoordinates[i]
+		}
+		measure.Direction = mgl32.Vec3{}
+		return true
+	}
+
+	// The barycentric coordinates are overwritten by the other faces.
+	simplex, closest, _ := closestOnTriangle32(
+		tetrahedron[(closestFace+1)%4],
+		tetrahedron[(closestFace+2)%4],
+		tetrahedron[(closestFace+3)%4],
+	)
+	measure.simplex, measure.Direction = simplex, closest
+	return true
+}
+
+// closestOnSegment32 returns the vertices of the segment from a to b making up the closest point to the origin,
+// and the closest point.
+func closestOnSegment32(a, b *vertex32) (simplex []*vertex32, closest mgl32.Vec3) {
+	ab := b.coordinate.Sub(a.coordinate)
+	lengthSquare := ab.LenSqr()
+	var t float32
+	if lengthSquare != 0.0 {
+		t = -a.coordinate.Dot(ab) / lengthSquare
+	}
+
+	switch {
+	case t <= 0.0:
+		a.barycentricCoordinate = 1.0
+		return []*vertex32{a}, a.coordinate
+	case t >= 1.0:
+		b.barycentricCoordinate = 1.0
+		return []*vertex32{b}, b.coordinate
+	default:
+		a.barycentricCoordinate = 1.0 - t
+		b.barycentricCoordinate = t
+		return []*vertex32{a, b}, a.coordinate.Add(ab.Mul(t))
+	}
+}
+
+// closestOnTriangle32 returns the vertices of the triangle making up the closest point to the origin,
+// and the closest point, by the Voronoi regions of the triangle.
+// It returns false if the triangle is degenerate.
+func closestOnTriangle32(a, b, c *vertex32) (simplex []*vertex32, closest mgl32.Vec3, ok bool) {
+	ab := b.coordinate.Sub(a.coordinate)
+	ac := c.coordinate.Sub(a.coordinate)
+
+	d1 := -ab.Dot(a.coordinate)
+	d2 := -ac.Dot(a.coordinate)
+	if d1 <= 0.0 && d2 <= 0.0 {
+		a.barycentricCoordinate = 1.0
+		return []*vertex32{a}, a.coordinate, true
+	}
+
+	d3 := -ab.Dot(b.coordinate)
+	d4 := -ac.Dot(b.coordinate)
+	if d3 >= 0.0 && d4 <= d3 {
+		b.barycentricCoordinate = 1.0
+		return []*vertex32{b}, b.coordinate, true
+	}
+
+	vc := d1*d4 - d3*d2
+	if vc <= 0.0 && d1 >= 0.0 && d3 <= 0.0 {
+		simplex, closest = closestOnSegment32(a, b)
+		return simplex, closest, true
+	}
+
+	d5 := -ab.Dot(c.coordinate)
+	d6 := -ac.Dot(c.coordinate)
+	if d6 >= 0.0 && d5 <= d6 {
+		c.barycentricCoordinate = 1.0
+		return []*vertex32{c}, c.coordinate, true
+	}
+
+	vb := d5*d2 - d1*d6
+	if vb <= 0.0 && d2 >= 0.0 && d6 <= 0.0 {
+		simplex, closest = closestOnSegment32(a, c)
+		return simplex, closest, true
+	}
+
+	va := d3*d6 - d5*d4
+	if va <= 0.0 && d4-d3 >= 0.0 && d5-d6 >= 0.0 {
+		simplex, closest = closestOnSegment32(b, c)
+		return simplex, closest, true
+	}
+
+	denominator := va + vb + vc
+	if denominator == 0.0 {
+		return nil, mgl32.Vec3{}, false
+	}
+	v := vb / denominator
+	w := vc / denominator
+	a.barycentricCoordinate = 1.0 - v - w
+	b.barycentricCoordinate = v
+	c.barycentricCoordinate = w
+	return []*vertex32{a, b, c}, a.coordinate.Add(ab.Mul(v)).Add(ac.Mul(w)), true
+}
+
+// blowUp expands the simplex containing the origin into a tetrahedron containing the origin.
+// It returns false if the Minkowski difference is too flat to contain a tetrahedron.
+func (measure *Measure32) blowUp() bool {
+	tolerance := measure.getTolerance()
+	support := func(direction mgl32.Vec3) *vertex32 {
+		return newVertex32(measure.ConvexHulls, direction.Mul(-1.0))
+	}
+
+	if len(measure.simplex) == 1 {
+		for _, direction := range []mgl32.Vec3{
+			{1.0, 0.0, 0.0},
+			{-1.0, 0.0, 0.0},
+			{0.0, 1.0, 0.0},
+			{0.0, -1.0, 0.0},
+			{0.0, 0.0, 1.0},
+			{0.0, 0.0, -1.0},
+		} {
+			newVertex := support(direction)
+			if newVertex.coordinate.Sub(measure.simplex[0].coordinate).Len() > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
+			}
+		}
+		if len(measure.simplex) == 1 {
+			return false
+		}
+	}
+
+	if len(measure.simplex) == 2 {
+		line := measure.simplex[1].coordinate.Sub(measure.simplex[0].coordinate).Normalize()
+
+		axis := mgl32.Vec3{}
+		minComponent := float32(math.Inf(1))
+		for i := 0; i < 3; i += 1 {
+			if mgl32.Abs(line[i]) < minComponent {
+				axis = mgl32.Vec3{}
+				axis[i] = 1.0
+				minComponent = mgl32.Abs(line[i])
+			}
+		}
+
+		direction := line.Cross(axis).Normalize()
+		rotation := mgl32.QuatRotate(math.Pi/3.0, line)
+		for i := 0; i < 6; i += 1 {
+			newVertex := support(direction)
+			if line.Cross(newVertex.coordinate.Sub(measure.simplex[0].coordinate)).Len() > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
+			}
+			direction = rotation.Rotate(direction)
+		}
+		if len(measure.simplex) == 2 {
+			return false
+		}
+	}
+
+	if len(measure.simplex) == 3 {
+		normal := measure.simplex[1].coordinate.Sub(measure.simplex[0].coordinate).Cross(
+			measure.simplex[2].coordinate.Sub(measure.simplex[0].coordinate),
+		).Normalize()
+
+		for _, direction := range []mgl32.Vec3{normal, normal.Mul(-1.0)} {
+			newVertex := support(direction)
+			if mgl32.Abs(normal.Dot(newVertex.coordinate.Sub(measure.simplex[0].coordinate))) > tolerance {
+				measure.simplex = append(measure.simplex, newVertex)
+				break
+			}
+		}
+		if len(measure.simplex) == 3 {
+			return false
+		}
+	}
+
+	return true
+}
+
+type face32 struct {
+	vertices [3]*vertex32
+	normal   mgl32.Vec3
+	distance float32 // From the origin to the plane of the face along normal
+}
+
+func newFace32(a, b, c *vertex32) *face32 {
+	face := &face32{
+		vertices: [3]*vertex32{a, b, c},
+		distance: float32(math.Inf(1)),
+	}
+
+	normal := b.coordinate.Sub(a.coordinate).Cross(c.coordinate.Sub(a.coordinate))
+	if length := normal.Len(); length != 0.0 {
+		face.normal = normal.Mul(1.0 / length)
+		face.distance = face.normal.Dot(a.coordinate)
+	}
+
+	return face
+}
+
+// isVisibleFrom returns whether the face faces the coordinate. The degenerate face is visible from anywhere.
+func (face *face32) isVisibleFrom(coordinate mgl32.Vec3) bool {
+	return math.IsInf(float64(face.distance), 1) || face.normal.Dot(coordinate)-face.distance > 0.0
+}
+
+// getBarycentricCoordinates returns the barycentric coordinates of the projection of the origin onto the face.
+func (face *face32) getBarycentricCoordinates() [3]float32 {
+	a := face.vertices[0].coordinate
+	b := face.vertices[1].coordinate
+	c := face.vertices[2].coordinate
+	projection := face.normal.Mul(face.distance)
+
+	normal := b.Sub(a).Cross(c.Sub(a))
+	denominator := normal.LenSqr()
+	u := c.Sub(b).Cross(projection.Sub(b)).Dot(normal) / denominator
+	v := a.Sub(c).Cross(projection.Sub(c)).Dot(normal) / denominator
+	return [3]float32{u, v, 1.0 - u - v}
+}
+
+// epa expands the polytope in the Minkowski difference from the tetrahedron containing the origin.
+// The faces of the polytope are counterclockwise from the outside.
+func (measure *Measure32) epa() {
+	if len(measure.simplex) != 4 {
+		log.Panic("Must not come here!")
+	}
+	tolerance := measure.getTolerance()
+	// The new vertices closer to the faces than the rounding errors make the faces inverted.
+	epsilon := 0x1p-23 * measure.scale
+
+	a, b, c, d := measure.simplex[0], measure.simplex[1], measure.simplex[2], measure.simplex[3]
+	if b.coordinate.Sub(a.coordinate).Cross(c.coordinate.Sub(a.coordinate)).Dot(d.coordinate.Sub(a.coordinate)) > 0.0 {
+		b, c = c, b
+	}
+	faces := []*face32{
+		newFace32(a, b, c),
+		newFace32(a, d, b),
+		newFace32(b, d, c),
+		newFace32(c, d, a),
+	}
+	// All the vertices ever added, so that no support point is added twice.
+	vertices := []*vertex32{a, b, c, d}
+
+	var closestFace *face32
+	for {
+		closestFace = faces[0]
+		for _, face := range faces[1:] {
+			if face.distance < closestFace.distance {
+				closestFace = face
+			}
+		}
+
+		newVertex := newVertex32(measure.ConvexHulls, closestFace.normal.Mul(-1.0))
+		isNear := false
+		for _, vertex := range vertices {
+			if vertex.coordinate.Sub(newVertex.coordinate).Len() <= epsilon {
+				isNear = true
+				break
+			}
+		}
+		if isNear {
+			break
+		}
+		if closestFace.normal.Dot(newVertex.coordinate)-closestFace.distance <= epsilon {
+			break
+		}
+
+		// Remove the faces visible from the new vertex, and connect the horizon to it.
+		faceOfEdge := map[[2]*vertex32]*face32{}
+		for _, face := range faces {
+			for i := 0; i < 3; i += 1 {
+				faceOfEdge[[2]*vertex32{face.vertices[i], face.vertices[(i+1)%3]}] = face
+			}
+		}
+		visibles := map[*face32]struct{}{closestFace: {}}
+		stack := []*face32{closestFace}
+		horizon := [][2]*vertex32{}
+		for len(stack) != 0 {
+			face := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for i := 0; i < 3; i += 1 {
+				edge := [2]*vertex32{face.vertices[i], face.vertices[(i+1)%3]}
+				twin, ok := faceOfEdge[[2]*vertex32{edge[1], edge[0]}]
+				if ok {
+					if _, ok := visibles[twin]; ok {
+						continue
+					}
+					if twin.isVisibleFrom(newVertex.coordinate) {
+						visibles[twin] = struct{}{}
+						stack = append(stack, twin)
+						continue
+					}
+				}
+				horizon = append(horizon, edge)
+			}
+		}
+		if len(horizon) == 0 {
+			break
+		}
+
+		newFaces := make([]*face32, 0, len(faces)-len(visibles)+len(horizon))
+		for _, face := range faces {
+			if _, ok := visibles[face]; !ok {
+				newFaces = append(newFaces, face)
+			}
+		}
+		for _, edge := range horizon {
+			newFaces = append(newFaces, newFace32(edge[0], edge[1], newVertex))
+		}
+		faces = newFaces
+		vertices = append(vertices, newVertex)
+	}
+
+	// The coplanar faces are as close as the closest face, so choose the one containing the projection.
+	barycentricCoordinates := closestFace.getBarycentricCoordinates()
+	for _, face := range faces {
+		if face == closestFace || face.distance-closestFace.distance > tolerance {
+			continue
+		}
+		coordinates := face.getBarycentricCoordinates()
+		if minOf3(coordinates) > minOf3(barycentricCoordinates) {
+			closestFace = face
+			barycentricCoordinates = coordinates
+		}
+	}
+
+	measure.simplex = measure.simplex[:0]
+	for i, vertex := range closestFace.vertices {
+		vertex.barycentricCoordinate = max32(barycentricCoordinates[i], 0.0)
+		measure.simplex = append(measure.simplex, vertex)
+	}
+	measure.Direction = closestFace.normal.Mul(closestFace.distance)
+	measure.updatePoints()
+	measure.updateOns()
+	measure.Distance = -closestFace.distance
+	measure.Termination = Expanded
+}
+
+func minOf3(values [3]float32) float32 {
+	return min32(values[0], min32(values[1], values[2]))
+}
+
+func (measure *Measure32) updatePoints() {
+	var denominator float32
+	for _, vertex := range measure.simplex {
+		denominator += vertex.barycentricCoordinate
+	}
+	denominator = 1.0 / denominator
+
+	measure.Points = [2]mgl32.Vec3{}
+	for i := 0; i < len(measure.Points); i += 1 {
+		for _, vertex := range measure.simplex {
+			measure.Points[i] = measure.Points[i].Add(measure.ConvexHulls[i][vertex.indices[i]].Mul(denominator * vertex.barycentricCoordinate))
+		}
+	}
+}
+
+func (measure *Measure32) updateOns() {
+	measure.Ons = [2]map[int]struct{}{
+		{},
+		{},
+	}
+	for i := 0; i < len(measure.Points); i += 1 {
+		for _, vertex := range measure.simplex {
+			measure.Ons[i][vertex.indices[i]] = struct{}{}
+		}
+	}
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,127 @@
+package closest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestMeasure32MeasureDistance(t *testing.T) {
+	for _, testCase := range []struct {
+		center             mgl32.Vec3
+		correctDistance    float32
+		correctTermination Termination
+	}{
+		{
+			center:             mgl32.Vec3{3.0, 0.5, 0.5},
+			correctDistance:    1.0,
+			correctTermination: Converged,
+		},
+		{
+			center:             mgl32.Vec3{2.0, 0.5, 0.5},
+			correctDistance:    0.0,
+			correctTermination: Touched,
+		},
+		{
+			center:             mgl32.Vec3{1.5, 0.25, 0.25},
+			correctDistance:    -0.5,
+			correctTermination: Expanded,
+		},
+	} {
+		measure := Measure32{
+			ConvexHulls: [2][]*mgl32.Vec3{
+				newCube32(mgl32.Vec3{}, 1.0),
+				newCube32(testCase.center, 1.0),
+			},
+		}
+		measure.MeasureDistance()
+		if mgl32.Abs(measure.Distance-testCase.correctDistance) > 1e-5 {
+			t.Errorf("%v: Distance %v, want %v", testCase.center, measure.Distance, testCase.correctDistance)
+		}
+		if measure.Termination != testCase.correctTermination {
+			t.Errorf("%v: Termination %v, want %v", testCase.center, measure.Termination, testCase.correctTermination)
+		}
+	}
+}
+
+func TestMeasure32MeasureDistance_NoVertices(t *testing.T) {
+	measure := Measure32{
+		ConvexHulls: [2][]*mgl32.Vec3{
+			newCube32(mgl32.Vec3{}, 1.0),
+			{},
+		},
+	}
+	measure.MeasureDistance()
+	if measure.Termination != NoVertices {
+		t.Errorf("Termination %v, want %v", measure.Termination, NoVertices)
+	}
+}
+
+// TestMeasure32MeasureDistanceRandomly compares Measure32 with Measure on the same convex hulls.
+func TestMeasure32MeasureDistanceRandomly(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	newConvexHulls := func() ([]*mgl32.Vec3, []*mgl64.Vec3) {
+		center := mgl32.Vec3{
+			float32(random.Float64()*4.0 - 2.0),
+			float32(random.Float64()*4.0 - 2.0),
+			float32(random.Float64()*4.0 - 2.0),
+		}
+		convexHull32 := make([]*mgl32.Vec3, random.Intn(8)+1)
+		convexHull64 := make([]*mgl64.Vec3, len(convexHull32))
+		for i := range convexHull32 {
+			vertex := center.Add(mgl32.Vec3{
+				float32(random.Float64()*2.0 - 1.0),
+				float32(random.Float64()*2.0 - 1.0),
+				float32(random.Float64()*2.0 - 1.0),
+			})
+			convexHull32[i] = &vertex
+			convexHull64[i] = &mgl64.Vec3{float64(vertex[0]), float64(vertex[1]), float64(vertex[2])}
+		}
+		return convexHull32, convexHull64
+	}
+
+	for i := 0; i < 1000; i += 1 {
+		convexHull32A, convexHull64A := newConvexHulls()
+		convexHull32B, convexHull64B := newConvexHulls()
+		measure32 := Measure32{
+			ConvexHulls: [2][]*mgl32.Vec3{convexHull32A, convexHull32B},
+		}
+		measure64 := Measure{
+			ConvexHulls: [2][]*mgl64.Vec3{convexHull64A, convexHull64B},
+		}
+
+		for _, isNonnegative := range []bool{false, true} {
+			if isNonnegative {
+				measure32.MeasureNonnegativeDistance()
+				measure64.MeasureNonnegativeDistance()
+			} else {
+				measure32.MeasureDistance()
+				measure64.MeasureDistance()
+			}
+
+			// The coordinates are about 3, so the rounding errors of float32 are about 1e-6.
+			if math.Abs(float64(measure32.Distance)-measure64.Distance) > 1e-4 {
+				t.Fatalf("%v, nonnegative %v: Distance %v, want %v of Measure", i, isNonnegative, measure32.Distance, measure64.Distance)
+			}
+			if measure64.Distance > 0.0 {
+				// The closest points may not be unique, but they are as far as the distance.
+				length := measure32.Points[1].Sub(measure32.Points[0]).Len()
+				if math.Abs(float64(length)-measure64.Distance) > 1e-4 {
+					t.Fatalf("%v: The closest points are %v apart, want %v of Measure", i, length, measure64.Distance)
+				}
+			}
+		}
+	}
+}
+
+func newCube32(center mgl32.Vec3, halfSize float32) []*mgl32.Vec3 {
+	cube := []*mgl32.Vec3{}
+	for _, vertex := range newCube(mgl64.Vec3{float64(center[0]), float64(center[1]), float64(center[2])}, float64(halfSize)) {
+		cube = append(cube, &mgl32.Vec3{float32(vertex[0]), float32(vertex[1]), float32(vertex[2])})
+	}
+
+	return cube
+}
@@ -1,6 +1,7 @@
 package closest
 
 import (
+	"math"
 	"math/rand"
 	"time"
 
@@ -78,10 +79,6 @@ func TestMeasureNonnegativeDistance_OutOfTetrahedron(t *testing.T) {
 }
 
 func TestMeasureNonnegativeDistance_InOfTetrahedron(t *testing.T) {
-	if testing.Short() {
-		t.Skip("TODO: Make this test succeed.")
-	}
-
 	testMeasureNonnegativeDistance(
 		t,
 		0.0,
@@ -227,20 +224,23 @@ func testMeasureNonnegativeDistance(
 	correctDistance float64,
 	convexHull0, convexHull1 []*mgl64.Vec3,
 ) {
-	measure := Measure{
-		ConvexHulls: [2][]*mgl64.Vec3{
-			convexHull0,
-			convexHull1,
-		},
-	}
+	for _, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+		measure := Measure{
+			ConvexHulls: [2][]*mgl64.Vec3{
+				convexHull0,
+				convexHull1,
+			},
+			SimplexSolver: simplexSolver,
+		}
 
-	start := time.Now()
-	measure.MeasureNonnegativeDistance()
-	t.Log("Time: ", time.Since(start))
+		start := time.Now()
+		measure.MeasureNonnegativeDistance()
+		t.Log("Time: ", time.Since(start))
 
-	difference := cmp.Diff(measure.Distance, correctDistance, option)
-	if difference != "" {
-		t.Error(difference)
+		difference := cmp.Diff(measure.Distance, correctDistance, option)
+		if difference != "" {
+			t.Error(simplexSolver, difference)
+		}
 	}
 }
 
@@ -354,33 +354,406 @@ func TestMeasureDistance_DistanceNaN(t *testing.T) {
 	}
 }
 
+func TestMeasureDistance_Touching(t *testing.T) {
+	testMeasureDistance(
+		t,
+		0.0,
+		newCube(mgl64.Vec3{0.0, 0.0, 0.0}, 1.0),
+		newCube(mgl64.Vec3{2.0, 0.5, 0.25}, 1.0),
+	)
+}
+
+func TestMeasureDistance_Coplanar(t *testing.T) {
+	// The Minkowski difference is flat, so the depth is zero.
+	testMeasureDistance(
+		t,
+		0.0,
+		[]*mgl64.Vec3{
+			{0.0, 0.0, 1.0},
+			{2.0, 0.0, 1.0},
+			{2.0, 2.0, 1.0},
+			{0.0, 2.0, 1.0},
+		},
+		[]*mgl64.Vec3{
+			{1.0, 1.0, 1.0},
+			{3.0, 1.0, 1.0},
+			{3.0, 3.0, 1.0},
+		},
+	)
+}
+
+func TestMeasureDistance_Segment(t *testing.T) {
+	// The simplex of GJK ends with the segment through the origin.
+	testMeasureDistance(
+		t,
+		-0.5,
+		newCube(mgl64.Vec3{0.0, 0.0, 0.0}, 1.0),
+		[]*mgl64.Vec3{
+			{0.5, 0.0, -2.0},
+			{0.5, 0.0, 2.0},
+		},
+	)
+}
+
+func TestMeasureDistance_Deep(t *testing.T) {
+	// The polytope of EPA has many coplanar faces.
+	testMeasureDistance(
+		t,
+		-17.0,
+		newCube(mgl64.Vec3{0.0, 0.0, 0.0}, 10.0),
+		newCube(mgl64.Vec3{3.0, 1.0, 0.5}, 10.0),
+	)
+}
+
+func TestMeasureDistance_NearDuplicates(t *testing.T) {
+	// The support point of the closest face is a near-duplicate of a vertex of the polytope,
+	// which was added to it and inverted a face. Found by FuzzMeasureDistance.
+	convexHull0 := []*mgl64.Vec3{
+		{-8.063426776928369, 8.698822155280833e-77, 10.000497347584313},
+		{-8.094117647058823, 8.094117647058823, 6.909252350272434e-77},
+		{6.990216430476444e-77, -8.094117647058823, -9.594117647058823},
+		{6.990216430476837e-77, 6.990216430476444e-77, -8.094117647058823},
+		{8.562882906080747, -9.609758418443194, 1.398043286095289e-76},
+		{10.129595821839843, 10.107698237656324, 1.207373746603704e-153},
+		{1.398043286095289e-76, 1.398043286095289e-76, 1.398043286095289e-76},
+		{1.398043286095289e-76, 1.398043286095289e-76, 1.398043286095289e-76},
+	}
+	convexHull1 := []*mgl64.Vec3{
+		{1.207373746603704e-153, 1.207373746603704e-153, 1.207373746603704e-153},
+		{1.207373746603704e-153, 1.398043286095289e-76, 7.912644585742035e-149},
+	}
+	// The distance from the origin to the closest face of convexHull0
+	correctDistance := -4.645785216346557
+
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i += 1 {
+		direction := mgl64.Vec3{random.NormFloat64(), random.NormFloat64(), random.NormFloat64()}
+		for _, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+			for _, measure := range []Measure{
+				{ConvexHulls: [2][]*mgl64.Vec3{convexHull0, convexHull1}, Direction: direction},
+				{ConvexHulls: [2][]*mgl64.Vec3{convexHull1, convexHull0}, Direction: direction.Mul(-1.0)},
+			} {
+				measure.SimplexSolver = simplexSolver
+				measure.MeasureDistance()
+				if math.Abs(measure.Distance-correctDistance) > 1e-12 || measure.Termination != Expanded {
+					t.Fatal(simplexSolver, " ", direction, " Distance: ", measure.Distance, " Termination: ", measure.Termination)
+				}
+			}
+		}
+	}
+}
+
+func TestMeasureDistance_Sliver(t *testing.T) {
+	// A vertex of the polytope is on an edge within the rounding errors, so the face of them was so thin
+	// that its normal was made up of the rounding errors, and it was the closest. Found by FuzzMeasureDistance.
+	convexHull0 := []*mgl64.Vec3{
+		{9.999999999999996, -10, 10},
+		{10, -10, -10},
+		{-10, 10, 10},
+		{1.398043286095289e-76, 2.0025750849519e-76, 1.398043286095289e-76},
+		{16.188235294117646, 6.990216430476444e-77, 16.188235294117646},
+		{16.188235294117646, 16.192141544117646, 1.398043286095289e-76},
+		{-16.345127296214063, -9.594178922762412, 16.192174982324424},
+		{1.398043286095289e-76, 1.398043286095289e-76, 1.398043286095289e-76},
+	}
+	convexHull1 := []*mgl64.Vec3{
+		{11, -9.5, 13},
+		{11, -9.5, -7},
+		{-9, 10.5, 13},
+		{-16.000747680714255, 8.59608603305978, -8.594125306372122},
+	}
+	correctDistance := referenceDistance([2][]*mgl64.Vec3{convexHull0, convexHull1})
+
+	for _, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+		for _, measure := range []Measure{
+			{ConvexHulls: [2][]*mgl64.Vec3{convexHull0, convexHull1}},
+			{ConvexHulls: [2][]*mgl64.Vec3{convexHull1, convexHull0}},
+		} {
+			measure.SimplexSolver = simplexSolver
+			measure.MeasureDistance()
+			if math.Abs(measure.Distance-correctDistance) > 1e-9 || measure.Termination != Expanded {
+				t.Error(simplexSolver, " Distance: ", measure.Distance, " Reference: ", correctDistance, " Termination: ", measure.Termination)
+			}
+		}
+	}
+}
+
+func TestMeasureDistance_Tiny(t *testing.T) {
+	// The cross products of the Minkowski difference of the tiny coordinates underflow in their squares,
+	// which made the normals of the faces infinite. Found by FuzzMeasureDistance.
+	convexHull0 := []*mgl64.Vec3{
+		{1.398043286095289e-76, 1.3980432860953674e-76, 1.398043286095289e-76},
+		{1.3980432860953674e-76, 1.398043286095289e-76, 1.398043286095289e-76},
+		{1.398043286095446e-76, 1.3980432860958387e-76, 1.398043286095289e-76},
+	}
+	convexHull1 := []*mgl64.Vec3{
+		{1.3980446038700318e-76, 1.398043286095289e-76, 1.398043286095289e-76},
+		{1.3980432912428464e-76, 1.398043286096624e-76, 1.398043286094032e-76},
+		{1.398043286095289e-76, 1.398043286095289e-76, 1.398043286095289e-76},
+	}
+
+	for _, measure := range []Measure{
+		{ConvexHulls: [2][]*mgl64.Vec3{convexHull0, convexHull1}},
+		{ConvexHulls: [2][]*mgl64.Vec3{convexHull1, convexHull0}},
+	} {
+		measure.MeasureDistance()
+		if math.IsNaN(measure.Distance) || math.IsInf(measure.Distance, 0) || math.Abs(measure.Distance) > 1e-90 {
+			t.Error("Distance: ", measure.Distance, " Termination: ", measure.Termination)
+		}
+		for _, point := range measure.Points {
+			if point.Sub(*convexHull0[0]).Len() > 1e-80 {
+				t.Error("Points: ", measure.Points)
+			}
+		}
+	}
+}
+
 func testMeasureDistance(
 	t *testing.T,
 	correctDistance float64,
 	convexHull0, convexHull1 []*mgl64.Vec3,
 ) {
+	for _, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+		measure := Measure{
+			ConvexHulls: [2][]*mgl64.Vec3{
+				convexHull0,
+				convexHull1,
+			},
+			SimplexSolver: simplexSolver,
+		}
+
+		start := time.Now()
+		measure.MeasureDistance()
+		t.Log("Time: ", time.Since(start))
+
+		difference := cmp.Diff(measure.Distance, correctDistance, option)
+		if difference != "" {
+			t.Error(simplexSolver, difference)
+		}
+	}
+}
+
+func TestMeasureDistance_WarmStartsSimplex(t *testing.T) {
+	convexHull0 := make([]*mgl64.Vec3, 64)
+	for i := range convexHull0 {
+		convexHull0[i] = &mgl64.Vec3{
+			rand.Float64(),
+			rand.Float64(),
+			rand.Float64(),
+		}
+	}
+	convexHull1 := newCube(mgl64.Vec3{}, 0.5)
+
+	cold := Measure{}
+	warm := Measure{
+		WarmStartsSimplex: true,
+	}
+	coldIterationCount := 0
+	warmIterationCount := 0
+	for frame := 0; frame < 100; frame += 1 {
+		// The cube goes through the points slowly.
+		angle := 0.02 * float64(frame)
+		center := mgl64.Vec3{0.5 + 1.5*math.Cos(angle), 0.5, 0.5 + 1.5*math.Sin(angle)}
+		moved := newCube(center, 0.5)
+		for i := range convexHull1 {
+			*convexHull1[i] = *moved[i]
+		}
+
+		for _, measure := range []*Measure{&cold, &warm} {
+			measure.ConvexHulls = [2][]*mgl64.Vec3{
+				convexHull0,
+				convexHull1,
+			}
+			measure.MeasureDistance()
+		}
+		coldIterationCount += cold.IterationCount
+		warmIterationCount += warm.IterationCount
+
+		difference := cmp.Diff(warm.Distance, cold.Distance, cmpopts.EquateApprox(0, 1e-9))
+		if difference != "" {
+			t.Error(frame, difference)
+		}
+	}
+	t.Log("IterationCount: ", coldIterationCount, " -> ", warmIterationCount)
+
+	if warmIterationCount >= coldIterationCount {
+		t.Error("Warm start does not reduce iterations: ", coldIterationCount, " <= ", warmIterationCount)
+	}
+
+	// The indices of the last simplex are out of the new convex hull.
+	warm.ConvexHulls[0] = convexHull0[:1]
+	warm.MeasureDistance()
+	cold.ConvexHulls[0] = convexHull0[:1]
+	cold.MeasureDistance()
+	difference := cmp.Diff(warm.Distance, cold.Distance, option)
+	if difference != "" {
+		t.Error(difference)
+	}
+}
+
+func TestMeasure_Recenters(t *testing.T) {
+	// A thin slab and a tetrahedron in the dyadic coordinates, so adding the offsets below is exact.
+	correct := Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 0x1p-12, 1.0}),
+			{
+				{0.25, 0.125, 0.5},
+				{0.75, 0.0625, 0.25},
+				{0.5, 0.5, 0.75},
+				{0.375, 0.25, 0.125},
+			},
+		},
+	}
+	correct.MeasureDistance()
+
+	for _, offset := range []mgl64.Vec3{
+		{0x1p20, 0x1p22, -0x1p23},
+		{-0x1p24, 0x1p24, 0x1p24},
+		{3.0, -5.0, 0x1p24},
+	} {
+		translateds := [2][]*mgl64.Vec3{}
+		for i, convex := range correct.ConvexHulls {
+			for _, vertex := range convex {
+				translated := vertex.Add(offset)
+				translateds[i] = append(translateds[i], &translated)
+			}
+		}
+
+		measure := Measure{
+			ConvexHulls: translateds,
+			Recenters:   true,
+		}
+		measure.MeasureDistance()
+
+		if math.Abs(measure.Distance-correct.Distance) > 1e-12 {
+			t.Error(offset, measure.Distance, correct.Distance)
+		}
+		for i := range measure.Points {
+			if measure.Points[i].Sub(offset).Sub(correct.Points[i]).Len() > 1e-8 {
+				t.Error(offset, measure.Points[i].Sub(offset), correct.Points[i])
+			}
+		}
+
+		// ConvexHulls of the caller are neither replaced nor modified.
+		for i, convex := range measure.ConvexHulls {
+			for j, vertex := range convex {
+				if vertex != translateds[i][j] || vertex.Sub(offset) != *correct.ConvexHulls[i][j] {
+					t.Error("ConvexHulls are changed: ", *vertex)
+				}
+			}
+		}
+	}
+}
+
+func TestMeasure_recenter(t *testing.T) {
 	measure := Measure{
 		ConvexHulls: [2][]*mgl64.Vec3{
-			convexHull0,
-			convexHull1,
+			{{1e7 + 0.1, -3.0, -2e6}, {1e7 + 0.7, 5.0, -2e6 - 0.3}},
+			{{1e7 + 1.3, 0.0, -2e6 - 0.9}},
 		},
 	}
+	measure.recenter()
 
-	start := time.Now()
+	// Only the coordinates far from the origin are translated by the nearest ones.
+	if measure.center != (mgl64.Vec3{1e7 + 0.1, 0.0, -2e6}) {
+		t.Error(measure.center)
+	}
+	for i, convex := range measure.ConvexHulls {
+		for j, vertex := range convex {
+			recentered := measure.convexHulls[i][j]
+			if recentered.Add(measure.center) != *vertex || recentered == vertex {
+				t.Error("Not exact: ", *recentered, *vertex)
+			}
+		}
+	}
+
+	// The buffers are reused.
+	buffer := &measure.recentereds[0][0]
+	measure.recenter()
+	if &measure.recentereds[0][0] != buffer {
+		t.Error("The buffers are not reused.")
+	}
+}
+
+func TestMeasure_KeepsPolytope(t *testing.T) {
+	measure := Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 1.0, 1.0}),
+			newBox(mgl64.Vec3{0.75, 0.1, 0.2}, mgl64.Vec3{1.75, 1.1, 1.2}),
+		},
+		KeepsPolytope: true,
+	}
 	measure.MeasureDistance()
-	t.Log("Time: ", time.Since(start))
+	if measure.Polytope == nil || len(measure.Polytope.Triangles) < 4 {
+		t.Fatal(measure.Polytope)
+	}
 
-	difference := cmp.Diff(measure.Distance, correctDistance, option)
-	if difference != "" {
-		t.Error(difference)
+	// The polytope is closed and contains the origin.
+	edges := map[[2]int]int{}
+	for _, triangle := range measure.Polytope.Triangles {
+		for i := 0; i < 3; i += 1 {
+			edges[[2]int{triangle[i], triangle[(i+1)%3]}] += 1
+		}
+		a := *measure.Polytope.Vertices[triangle[0]]
+		b := *measure.Polytope.Vertices[triangle[1]]
+		c := *measure.Polytope.Vertices[triangle[2]]
+		if normal := b.Sub(a).Cross(c.Sub(a)); normal.Dot(a) < 0.0 {
+			t.Error("The origin is out of the face: ", a, b, c)
+		}
+	}
+	for edge, count := range edges {
+		if count != 1 || edges[[2]int{edge[1], edge[0]}] != 1 {
+			t.Error("Not closed at: ", edge)
+		}
+	}
+
+	measure.ConvexHulls[1] = newBox(mgl64.Vec3{2.0, 0.0, 0.0}, mgl64.Vec3{3.0, 1.0, 1.0})
+	measure.MeasureDistance()
+	if measure.Polytope != nil {
+		t.Error("The polytope is kept without EPA.")
 	}
 }
 
-func TestMeasureDistanceRandomly(t *testing.T) {
-	if testing.Short() {
-		t.Skip("TODO: Make this test succeed.")
+func TestMeasure_KeepsHistory(t *testing.T) {
+	measure := Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 1.0, 1.0}),
+			newBox(mgl64.Vec3{2.0, 0.5, 0.25}, mgl64.Vec3{3.0, 1.5, 1.25}),
+		},
+		KeepsHistory: true,
+	}
+	measure.MeasureDistance()
+	if len(measure.History) == 0 || len(measure.History) > measure.IterationCount {
+		t.Fatal(measure.History, measure.IterationCount)
+	}
+	for _, simplex := range measure.History {
+		if len(simplex) == 0 || len(simplex) > 4 {
+			t.Error(simplex)
+		}
+	}
+
+	// The last simplex contains the closest points.
+	last := measure.History[len(measure.History)-1]
+	for i, on := range measure.Ons {
+		for index := range on {
+			isFound := false
+			for _, indices := range last {
+				isFound = isFound || indices[i] == index
+			}
+			if !isFound {
+				t.Error("Ons[", i, "] is not in the last simplex: ", index, last)
+			}
+		}
 	}
 
+	measure.KeepsHistory = false
+	measure.MeasureDistance()
+	if measure.History != nil {
+		t.Error("The history is kept: ", measure.History)
+	}
+}
+
+func TestMeasureDistanceRandomly(t *testing.T) {
 	minDistance := 0.0
 	tryCount := 0
 	notCancelCount := 0
@@ -0,0 +1,37 @@
+package closest
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Mesh is a triangle mesh. If it is closed, it is regarded as a solid.
+type Mesh struct {
+	// Vertices are the vertices of the triangles.
+	Vertices []*mgl64.Vec3
+	// Triangles are counterclockwise seen from the outside, by the indices of Vertices.
+	Triangles [][3]int
+}
+
+// Bounds returns the minimum and the maximum corners of the axis-aligned bounding box of the mesh.
+func (mesh *Mesh) Bounds() (min, max mgl64.Vec3) {
+	return getBounds(mesh.Vertices)
+}
+
+func getBounds(convex []*mgl64.Vec3) (min, max mgl64.Vec3) {
+	if len(convex) == 0 {
+		return
+	}
+
+	min = *convex[0]
+	max = *convex[0]
+	for _, vertex := range convex {
+		for i := 0; i < 3; i += 1 {
+			min[i] = math.Min(min[i], vertex[i])
+			max[i] = math.Max(max[i], vertex[i])
+		}
+	}
+
+	return
+}
@@ -0,0 +1,74 @@
+package closest
+
+// PairCache keeps [Measure] of each pair of convex hulls between frames, so every pair from
+// a broad phase is measured with the last Direction even if the pairs are listed again every frame.
+// The pairs are ordered, so the pair of IDs 1 and 2 is different from the pair of IDs 2 and 1.
+type PairCache struct {
+	// In
+	// MaxAge is the number of the frames for which the pairs not used are kept. If this is zero, 1 is used.
+	MaxAge int
+	// WarmStartsSimplex is set to the new measures. See [Measure].
+	WarmStartsSimplex bool
+
+	// Out
+	// HitCount is the number of the calls of Get finding the kept measures.
+	HitCount int
+	// MissCount is the number of the calls of Get making new measures.
+	MissCount int
+	// EvictionCount is the number of the evicted pairs.
+	EvictionCount int
+
+	frame int
+	pairs map[[2]int]*cachedPair
+}
+
+type cachedPair struct {
+	measure *Measure
+	frame   int // The last frame when the pair is used
+}
+
+// Get returns the measure of the pair of the convex hulls with id0 and id1, kept from the last frames if any.
+// You must set ConvexHulls of the measure before measuring, because the convex hulls may move.
+func (cache *PairCache) Get(id0, id1 int) *Measure {
+	if cache.pairs == nil {
+		cache.pairs = map[[2]int]*cachedPair{}
+	}
+
+	key := [2]int{id0, id1}
+	pair, ok := cache.pairs[key]
+	if ok {
+		cache.HitCount += 1
+	} else {
+		cache.MissCount += 1
+		pair = &cachedPair{
+			measure: &Measure{
+				WarmStartsSimplex: cache.WarmStartsSimplex,
+			},
+		}
+		cache.pairs[key] = pair
+	}
+	pair.frame = cache.frame
+
+	return pair.measure
+}
+
+// NextFrame evicts the pairs not used for more than MaxAge frames, and starts the next frame.
+func (cache *PairCache) NextFrame() {
+	maxAge := cache.MaxAge
+	if maxAge <= 0 {
+		maxAge = 1
+	}
+
+	cache.frame += 1
+	for key, pair := range cache.pairs {
+		if cache.frame-pair.frame > maxAge {
+			delete(cache.pairs, key)
+			cache.EvictionCount += 1
+		}
+	}
+}
+
+// Len returns the number of the kept pairs.
+func (cache *PairCache) Len() int {
+	return len(cache.pairs)
+}
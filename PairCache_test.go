@@ -0,0 +1,53 @@
+package closest
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestPairCacheGet(t *testing.T) {
+	cubes := [][]*mgl64.Vec3{
+		newCube(mgl64.Vec3{0.0, 0.0, 0.0}, 1.0),
+		newCube(mgl64.Vec3{3.0, 0.0, 0.0}, 1.0),
+		newCube(mgl64.Vec3{0.0, 4.0, 0.0}, 1.0),
+	}
+
+	cache := PairCache{
+		MaxAge: 2,
+	}
+	measure := func(id0, id1 int) *Measure {
+		measure := cache.Get(id0, id1)
+		measure.ConvexHulls = [2][]*mgl64.Vec3{
+			cubes[id0],
+			cubes[id1],
+		}
+		measure.MeasureDistance()
+		return measure
+	}
+
+	first := measure(0, 1)
+	if first.Distance != 1.0 {
+		t.Error("Distance: ", first.Distance)
+	}
+	measure(0, 2)
+	cache.NextFrame()
+
+	if measure(0, 1) != first {
+		t.Error("The measure is not kept.")
+	}
+	if measure(1, 0) == first {
+		t.Error("The pairs must be ordered.")
+	}
+	cache.NextFrame()
+	measure(0, 1)
+	cache.NextFrame()
+
+	// The pair (0, 2) is not used for 3 frames, and (1, 0) for 2 frames.
+	if cache.Len() != 2 {
+		t.Error("Len: ", cache.Len())
+	}
+	if cache.HitCount != 2 || cache.MissCount != 3 || cache.EvictionCount != 1 {
+		t.Error("HitCount: ", cache.HitCount, " MissCount: ", cache.MissCount, " EvictionCount: ", cache.EvictionCount)
+	}
+}
@@ -0,0 +1,61 @@
+package closest
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PointMeasure is an all-in-one structure for calculating the closest point of a convex hull to a point.
+// Like [Measure], it stores the last direction, so measuring a nearby point next time is faster.
+type PointMeasure struct {
+	// In
+	// ConvexHull is measured the distance from.
+	ConvexHull []*mgl64.Vec3
+	// Point is measured the distance to.
+	Point mgl64.Vec3
+
+	// Out
+	// Distance is the signed distance from ConvexHull to Point.
+	// If this is negative, Point is inside ConvexHull and this represents depth.
+	Distance float64
+	// Direction is from ConvexHull to Point.
+	Direction mgl64.Vec3
+	// ClosestPoint is the closest point on ConvexHull.
+	ClosestPoint mgl64.Vec3
+	// On is the set of indices of the vertices that make up the simplex that contains ClosestPoint.
+	On map[int]struct{}
+
+	measure Measure
+}
+
+// MeasureDistance measures the signed distance from ConvexHull to Point, and updates Direction, ClosestPoint and On.
+func (pointMeasure *PointMeasure) MeasureDistance() {
+	pointMeasure.prepare()
+	pointMeasure.measure.MeasureDistance()
+	pointMeasure.update()
+}
+
+// MeasureNonnegativeDistance measures the distance from ConvexHull to Point, and updates Direction, ClosestPoint and On.
+// Distance is zero if Point is inside ConvexHull.
+func (pointMeasure *PointMeasure) MeasureNonnegativeDistance() {
+	pointMeasure.prepare()
+	pointMeasure.measure.MeasureNonnegativeDistance()
+	pointMeasure.update()
+}
+
+func (pointMeasure *PointMeasure) prepare() {
+	point := pointMeasure.Point
+	pointMeasure.measure.ConvexHulls = [2][]*mgl64.Vec3{
+		pointMeasure.ConvexHull,
+		{
+			&point,
+		},
+	}
+	pointMeasure.measure.Direction = pointMeasure.Direction
+}
+
+func (pointMeasure *PointMeasure) update() {
+	pointMeasure.Distance = pointMeasure.measure.Distance
+	pointMeasure.Direction = pointMeasure.measure.Direction
+	pointMeasure.ClosestPoint = pointMeasure.measure.Points[0]
+	pointMeasure.On = pointMeasure.measure.Ons[0]
+}
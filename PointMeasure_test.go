@@ -0,0 +1,145 @@
+package closest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newCube(center mgl64.Vec3, halfSize float64) []*mgl64.Vec3 {
+	return newBox(center.Sub(mgl64.Vec3{halfSize, halfSize, halfSize}), center.Add(mgl64.Vec3{halfSize, halfSize, halfSize}))
+}
+
+func newBox(min mgl64.Vec3, max mgl64.Vec3) (box []*mgl64.Vec3) {
+	for i := 0; i < 8; i += 1 {
+		vertex := min
+		for j := 0; j < 3; j += 1 {
+			if i&(1<<j) != 0 {
+				vertex[j] = max[j]
+			}
+		}
+		box = append(box, &vertex)
+	}
+
+	return
+}
+
+// boxDistance is the exact signed distance from the cube [-1, 1]³.
+func boxDistance(point mgl64.Vec3) float64 {
+	outside := mgl64.Vec3{}
+	inside := math.Inf(-1)
+	for axis := 0; axis < 3; axis += 1 {
+		d := math.Abs(point[axis]) - 1.0
+		outside[axis] = math.Max(d, 0.0)
+		inside = math.Max(inside, d)
+	}
+
+	return outside.Len() + math.Min(inside, 0.0)
+}
+
+func TestPointMeasureMeasureDistance(t *testing.T) {
+	testCases := []struct {
+		point           mgl64.Vec3
+		correctDistance float64
+		correctClosest  mgl64.Vec3
+	}{
+		{mgl64.Vec3{3.0, 0.0, 0.0}, 2.0, mgl64.Vec3{1.0, 0.0, 0.0}},
+		{mgl64.Vec3{2.0, 2.0, 1.0}, math.Sqrt2, mgl64.Vec3{1.0, 1.0, 1.0}},
+		{mgl64.Vec3{1.5, -0.5, 0.25}, 0.5, mgl64.Vec3{1.0, -0.5, 0.25}},
+	}
+
+	pointMeasure := PointMeasure{
+		ConvexHull: newCube(mgl64.Vec3{}, 1.0),
+	}
+	for _, testCase := range testCases {
+		pointMeasure.Point = testCase.point
+		pointMeasure.MeasureDistance()
+
+		difference := cmp.Diff(pointMeasure.Distance, testCase.correctDistance, option)
+		if difference != "" {
+			t.Error(testCase.point, difference)
+		}
+		difference = cmp.Diff(pointMeasure.ClosestPoint, testCase.correctClosest, option)
+		if difference != "" {
+			t.Error(testCase.point, difference)
+		}
+	}
+}
+
+func TestPointMeasureMeasureDistance_Inside(t *testing.T) {
+	testCases := []struct {
+		point           mgl64.Vec3
+		correctDistance float64
+		correctClosest  mgl64.Vec3
+	}{
+		{mgl64.Vec3{0.0, 0.0, 0.5}, -0.5, mgl64.Vec3{0.0, 0.0, 1.0}},
+		{mgl64.Vec3{-0.75, 0.1, 0.2}, -0.25, mgl64.Vec3{-1.0, 0.1, 0.2}},
+		{mgl64.Vec3{1.0, 0.5, 0.5}, 0.0, mgl64.Vec3{1.0, 0.5, 0.5}},
+	}
+
+	pointMeasure := PointMeasure{
+		ConvexHull: newCube(mgl64.Vec3{}, 1.0),
+	}
+	for _, testCase := range testCases {
+		pointMeasure.Point = testCase.point
+		pointMeasure.MeasureDistance()
+
+		difference := cmp.Diff(pointMeasure.Distance, testCase.correctDistance, option)
+		if difference != "" {
+			t.Error(testCase.point, difference)
+		}
+		difference = cmp.Diff(pointMeasure.ClosestPoint, testCase.correctClosest, option)
+		if difference != "" {
+			t.Error(testCase.point, difference)
+		}
+	}
+}
+
+func TestPointMeasureMeasureNonnegativeDistance(t *testing.T) {
+	pointMeasure := PointMeasure{
+		ConvexHull: newCube(mgl64.Vec3{}, 1.0),
+		Point:      mgl64.Vec3{0.0, 0.2, 0.5},
+	}
+	pointMeasure.MeasureNonnegativeDistance()
+
+	difference := cmp.Diff(pointMeasure.Distance, 0.0, option)
+	if difference != "" {
+		t.Error(difference)
+	}
+}
+
+func TestDistanceFieldSample(t *testing.T) {
+	field := DistanceField{
+		ConvexHull: newCube(mgl64.Vec3{}, 1.0),
+		Min:        mgl64.Vec3{-2.0, -2.0, -2.0},
+		Max:        mgl64.Vec3{2.0, 2.0, 2.0},
+		Counts:     [3]int{9, 8, 7},
+	}
+	err := field.Sample()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(field.Distances) != 9*8*7 {
+		t.Fatal("Wrong sample count:", len(field.Distances))
+	}
+	for k := 0; k < field.Counts[2]; k += 1 {
+		for j := 0; j < field.Counts[1]; j += 1 {
+			for i := 0; i < field.Counts[0]; i += 1 {
+				position := field.Position(i, j, k)
+				difference := cmp.Diff(field.At(i, j, k), boxDistance(position), option)
+				if difference != "" {
+					t.Error(position, difference)
+				}
+			}
+		}
+	}
+
+	field.Counts = [3]int{-1, -1, 5}
+	err = field.Sample()
+	if err == nil || len(field.Distances) != 0 {
+		t.Error("The negative counts are sampled: ", field.Distances)
+	}
+}
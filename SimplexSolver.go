@@ -0,0 +1,227 @@
+package closest
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SimplexSolver is the method for finding the closest point of the simplex to the origin in GJK.
+type SimplexSolver int
+
+const (
+	// VoronoiRegions tests the Voronoi regions of the simplex. It is the default.
+	VoronoiRegions SimplexSolver = iota
+	// SignedVolumes compares the signs of the signed volumes of the simplex and its projections
+	// by Montanari et al. It never regards the simplex as degenerate, and it translates the convex hulls
+	// far from the origin near it exactly, so it is robust for large coordinates.
+	SignedVolumes
+)
+
+var simplexSolverNames = [...]string{
+	VoronoiRegions: "VoronoiRegions",
+	SignedVolumes:  "SignedVolumes",
+}
+
+func (simplexSolver SimplexSolver) String() string {
+	if simplexSolver < 0 || int(simplexSolver) >= len(simplexSolverNames) {
+		return "SimplexSolver(" + strconv.Itoa(int(simplexSolver)) + ")"
+	}
+	return simplexSolverNames[simplexSolver]
+}
+
+// MarshalText encodes simplexSolver as its name.
+func (simplexSolver SimplexSolver) MarshalText() ([]byte, error) {
+	if simplexSolver < 0 || int(simplexSolver) >= len(simplexSolverNames) {
+		return nil, errors.New("closest: unknown " + simplexSolver.String())
+	}
+	return []byte(simplexSolverNames[simplexSolver]), nil
+}
+
+// UnmarshalText decodes the name of a simplex solver.
+func (simplexSolver *SimplexSolver) UnmarshalText(text []byte) error {
+	for i, name := range simplexSolverNames {
+		if string(text) == name {
+			*simplexSolver = SimplexSolver(i)
+			return nil
+		}
+	}
+	return errors.New("closest: unknown simplex solver: " + strconv.Quote(string(text)))
+}
+
+// solveSignedVolumes reduces the simplex to the vertices making up the closest point to the origin,
+// and updates their barycentric coordinates.
+func solveSignedVolumes(simplex []*vertex) (reduced []*vertex) {
+	points := make([]mgl64.Vec3, len(simplex))
+	for i, vertex := range simplex {
+		points[i] = vertex.coordinate
+	}
+
+	var lambdas []float64
+	switch len(simplex) {
+	case 1:
+		lambdas = []float64{1.0}
+	case 2:
+		lambdas = signedVolumes1D(points)
+	case 3:
+		lambdas = signedVolumes2D(points)
+	case 4:
+		lambdas = signedVolumes3D(points)
+	}
+
+	for i, lambda := range lambdas {
+		if lambda <= 0.0 {
+			continue
+		}
+		simplex[i].barycentricCoordinate = lambda
+		reduced = append(reduced, simplex[i])
+	}
+
+	return
+}
+
+// signedVolumes1D returns the barycentric coordinates of the closest point on the segment.
+func signedVolumes1D(points []mgl64.Vec3) (lambdas []float64) {
+	lambdas = make([]float64, 2)
+
+	t := points[1].Sub(points[0])
+	lengthSquare := t.LenSqr()
+	if lengthSquare == 0.0 {
+		lambdas[1] = 1.0
+		return
+	}
+	projection := points[0].Sub(t.Mul(points[0].Dot(t) / lengthSquare))
+
+	// The projection onto the axis with the longest segment
+	axis := getIndexOfMaxAbs(t)
+	mu := points[0][axis] - points[1][axis]
+	c := [2]float64{
+		projection[axis] - points[1][axis],
+		points[0][axis] - projection[axis],
+	}
+	if hasSameSign(mu, c[0]) && hasSameSign(mu, c[1]) {
+		lambdas[0] = c[0] / mu
+		lambdas[1] = c[1] / mu
+		return
+	}
+
+	// The origin is beyond either of the vertices.
+	if hasSameSign(mu, c[0]) {
+		lambdas[0] = 1.0
+	} else {
+		lambdas[1] = 1.0
+	}
+	return
+}
+
+// signedVolumes2D returns the barycentric coordinates of the closest point on the triangle.
+func signedVolumes2D(points []mgl64.Vec3) (lambdas []float64) {
+	normal := points[1].Sub(points[0]).Cross(points[2].Sub(points[0]))
+	lengthSquare := normal.LenSqr()
+
+	// The projection onto the plane with the largest area
+	c := [3]float64{}
+	mu := 0.0
+	if lengthSquare != 0.0 {
+		projection := normal.Mul(points[0].Dot(normal) / lengthSquare)
+		axis := getIndexOfMaxAbs(normal)
+		x := (axis + 1) % 3
+		y := (axis + 2) % 3
+		area := func(a, b, c mgl64.Vec3) float64 {
+			return (b[x]-a[x])*(c[y]-a[y]) - (b[y]-a[y])*(c[x]-a[x])
+		}
+
+		mu = normal[axis]
+		c[0] = area(projection, points[1], points[2])
+		c[1] = area(points[0], projection, points[2])
+		c[2] = area(points[0], points[1], projection)
+		if hasSameSign(mu, c[0]) && hasSameSign(mu, c[1]) && hasSameSign(mu, c[2]) {
+			lambdas = []float64{c[0] / mu, c[1] / mu, c[2] / mu}
+			return
+		}
+	}
+
+	// The closest point is on the edges opposite to the vertices beyond which the origin is.
+	minLengthSquare := math.Inf(1)
+	for i := 0; i < 3; i += 1 {
+		if lengthSquare != 0.0 && hasSameSign(mu, c[i]) {
+			continue
+		}
+
+		edge := []mgl64.Vec3{points[(i+1)%3], points[(i+2)%3]}
+		edgeLambdas := signedVolumes1D(edge)
+		closest := edge[0].Mul(edgeLambdas[0]).Add(edge[1].Mul(edgeLambdas[1]))
+		if closest.LenSqr() < minLengthSquare {
+			minLengthSquare = closest.LenSqr()
+			lambdas = make([]float64, 3)
+			lambdas[(i+1)%3] = edgeLambdas[0]
+			lambdas[(i+2)%3] = edgeLambdas[1]
+		}
+	}
+	return
+}
+
+// signedVolumes3D returns the barycentric coordinates of the closest point on the tetrahedron.
+func signedVolumes3D(points []mgl64.Vec3) (lambdas []float64) {
+	volume := func(a, b, c, d mgl64.Vec3) float64 {
+		return b.Sub(a).Dot(c.Sub(a).Cross(d.Sub(a)))
+	}
+
+	mu := volume(points[0], points[1], points[2], points[3])
+	c := [4]float64{}
+	for i := 0; i < 4; i += 1 {
+		replaced := [4]mgl64.Vec3{points[0], points[1], points[2], points[3]}
+		replaced[i] = mgl64.Vec3{}
+		c[i] = volume(replaced[0], replaced[1], replaced[2], replaced[3])
+	}
+	if hasSameSign(mu, c[0]) && hasSameSign(mu, c[1]) && hasSameSign(mu, c[2]) && hasSameSign(mu, c[3]) {
+		lambdas = []float64{c[0] / mu, c[1] / mu, c[2] / mu, c[3] / mu}
+		return
+	}
+
+	// The closest point is on the faces opposite to the vertices beyond which the origin is.
+	minLengthSquare := math.Inf(1)
+	for i := 0; i < 4; i += 1 {
+		if mu != 0.0 && hasSameSign(mu, c[i]) {
+			continue
+		}
+
+		face := []mgl64.Vec3{}
+		indices := []int{}
+		for j := 0; j < 4; j += 1 {
+			if j != i {
+				face = append(face, points[j])
+				indices = append(indices, j)
+			}
+		}
+		faceLambdas := signedVolumes2D(face)
+		closest := mgl64.Vec3{}
+		for j := range face {
+			closest = closest.Add(face[j].Mul(faceLambdas[j]))
+		}
+		if closest.LenSqr() < minLengthSquare {
+			minLengthSquare = closest.LenSqr()
+			lambdas = make([]float64, 4)
+			for j, index := range indices {
+				lambdas[index] = faceLambdas[j]
+			}
+		}
+	}
+	return
+}
+
+func hasSameSign(a, b float64) bool {
+	return (a > 0.0 && b > 0.0) || (a < 0.0 && b < 0.0)
+}
+
+func getIndexOfMaxAbs(vector mgl64.Vec3) (index int) {
+	for i := 1; i < 3; i += 1 {
+		if math.Abs(vector[i]) > math.Abs(vector[index]) {
+			index = i
+		}
+	}
+
+	return
+}
@@ -0,0 +1,93 @@
+package closest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSignedVolumesRandomly(t *testing.T) {
+	for n := 0; n < 1000; n += 1 {
+		convexHulls := [2][]*mgl64.Vec3{}
+		for i := range convexHulls {
+			convexHulls[i] = make([]*mgl64.Vec3, rand.Intn(8)+1)
+			for j := range convexHulls[i] {
+				convexHulls[i][j] = &mgl64.Vec3{
+					rand.Float64(),
+					rand.Float64(),
+					rand.Float64(),
+				}
+			}
+		}
+
+		measures := [2]Measure{}
+		for i, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+			measures[i].ConvexHulls = convexHulls
+			measures[i].SimplexSolver = simplexSolver
+			measures[i].MeasureDistance()
+		}
+
+		if math.Abs(measures[0].Distance-measures[1].Distance) > 1e-12 {
+			t.Error(n, measures[0].Distance, measures[1].Distance)
+		}
+	}
+}
+
+// TestSignedVolumes_LargeCoordinates measures the convex hulls far from the origin,
+// and compares them with the same convex hulls around the origin.
+func TestSignedVolumes_LargeCoordinates(t *testing.T) {
+	for _, offset := range []float64{1e3, 1e5, 1e6, 1e7} {
+		maxErrors := [2]float64{}
+		for n := 0; n < 300; n += 1 {
+			// Thin convex hulls like the ones in TestMeasureNonnegativeDistance_MinError2
+			size := mgl64.Vec3{1.0, math.Pow(10.0, -4.0*rand.Float64()), 1.0}
+			translation := mgl64.Vec3{offset, 0.5 * offset, -0.7 * offset}
+
+			convexHulls := [2][]*mgl64.Vec3{}
+			translateds := [2][]*mgl64.Vec3{}
+			for i := range convexHulls {
+				for j := rand.Intn(8) + 1; j > 0; j -= 1 {
+					translated := mgl64.Vec3{
+						size[0] * rand.Float64(),
+						size[1] * rand.Float64(),
+						size[2] * rand.Float64(),
+					}.Add(translation)
+					// Subtracting the translation is exact, so both are the same convex hulls.
+					vertex := translated.Sub(translation)
+					convexHulls[i] = append(convexHulls[i], &vertex)
+					translateds[i] = append(translateds[i], &translated)
+				}
+			}
+
+			correct := Measure{
+				ConvexHulls: convexHulls,
+			}
+			correct.MeasureDistance()
+
+			for i, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+				measure := Measure{
+					ConvexHulls:   translateds,
+					SimplexSolver: simplexSolver,
+				}
+				measure.MeasureDistance()
+
+				maxErrors[i] = math.Max(maxErrors[i], math.Abs(measure.Distance-correct.Distance))
+				if simplexSolver != SignedVolumes {
+					continue
+				}
+
+				if math.Abs(measure.Distance-correct.Distance) > 1e-9 {
+					t.Error(offset, n, measure.Distance, correct.Distance)
+				}
+				for j := range measure.Points {
+					if measure.Points[j].Sub(translation).Sub(correct.Points[j]).Len() > 1e-8 {
+						t.Error(offset, n, measure.Points[j].Sub(translation), correct.Points[j])
+					}
+				}
+			}
+		}
+		t.Log("Offset: ", offset, " Errors of VoronoiRegions and SignedVolumes: ", maxErrors)
+	}
+}
@@ -0,0 +1,196 @@
+package closest
+
+import (
+	"log"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bound is the relation of a simplified convex hull to the original one.
+type Bound int
+
+const (
+	// Outer simplified convex hulls contain the original one. They are conservative for collision checks.
+	Outer Bound = iota
+	// Inner simplified convex hulls are contained by the original one. They are optimistic for collision checks.
+	Inner
+)
+
+// Simplify reduces the convex hull to at most count face planes if bound is [Outer],
+// or at most count vertices if bound is [Inner].
+// It also returns the Hausdorff distance between the convex hull and the simplified one,
+// so you can trade accuracy for speed before measuring.
+//
+// Inner simplified convex hulls share Vertices with the convex hull, and their Indices are
+// the indices in the points given to [NewHull]. Outer ones have new Vertices and no Indices.
+// Outer ones need at least 4 face planes, so count less than 4 is regarded as 4.
+func (hull *Hull) Simplify(count int, bound Bound) (simplified *Hull, hausdorffDistance float64) {
+	if len(hull.Vertices) == 0 {
+		return &Hull{}, 0.0
+	}
+
+	scale := 0.0
+	for _, vertex := range hull.Vertices {
+		for i := 0; i < 3; i += 1 {
+			scale = math.Max(scale, math.Abs(vertex[i]))
+		}
+	}
+	epsilon := 1e-12 * scale
+
+	switch bound {
+	case Inner:
+		simplified, hausdorffDistance = hull.simplifyInner(count, epsilon)
+	case Outer:
+		simplified, hausdorffDistance = hull.simplifyOuter(count, epsilon)
+	default:
+		log.Panic("Must not come here!")
+	}
+
+	return
+}
+
+// simplifyInner adds the vertex furthest from the simplified convex hull one by one.
+func (hull *Hull) simplifyInner(count int, epsilon float64) (simplified *Hull, hausdorffDistance float64) {
+	if count < 1 {
+		count = 1
+	}
+
+	selected := []*mgl64.Vec3{}
+	indices := []int{}
+
+	// Start from the extreme vertex along any axis.
+	first := 0
+	for i, vertex := range hull.Vertices {
+		if vertex[0] < hull.Vertices[first][0] {
+			first = i
+		}
+	}
+	selected = append(selected, hull.Vertices[first])
+	indices = append(indices, first)
+	simplified = NewHull(selected)
+
+	for {
+		furthest := -1
+		hausdorffDistance = 0.0
+		for i, vertex := range hull.Vertices {
+			distance := simplified.ClosestPoint(*vertex).Sub(*vertex).Len()
+			if distance > hausdorffDistance {
+				furthest = i
+				hausdorffDistance = distance
+			}
+		}
+		if hausdorffDistance <= epsilon || len(selected) >= count {
+			break
+		}
+
+		selected = append(selected, hull.Vertices[furthest])
+		indices = append(indices, furthest)
+		simplified = NewHull(selected)
+	}
+
+	for i, index := range simplified.Indices {
+		simplified.Indices[i] = indices[index]
+		if hull.Indices != nil {
+			simplified.Indices[i] = hull.Indices[indices[index]]
+		}
+	}
+
+	return
+}
+
+// simplifyOuter intersects supporting planes, adding the face plane that cuts off
+// the vertex furthest from the convex hull one by one.
+func (hull *Hull) simplifyOuter(count int, epsilon float64) (simplified *Hull, hausdorffDistance float64) {
+	if count < 4 {
+		count = 4
+	}
+
+	planes := []plane{}
+	addSupportingPlane := func(normal mgl64.Vec3) {
+		normal = normal.Normalize()
+		offset := math.Inf(-1)
+		for _, vertex := range hull.Vertices {
+			offset = math.Max(offset, normal.Dot(*vertex))
+		}
+		planes = append(planes, plane{
+			normal: normal,
+			offset: offset,
+		})
+	}
+	for _, normal := range []mgl64.Vec3{
+		{1.0, 1.0, 1.0},
+		{1.0, -1.0, -1.0},
+		{-1.0, 1.0, -1.0},
+		{-1.0, -1.0, 1.0},
+	} {
+		addSupportingPlane(normal)
+	}
+
+	for {
+		simplified = intersectPlanes(planes, epsilon)
+
+		furthest := mgl64.Vec3{}
+		hausdorffDistance = 0.0
+		for _, vertex := range simplified.Vertices {
+			distance := hull.ClosestPoint(*vertex).Sub(*vertex).Len()
+			if distance > hausdorffDistance {
+				furthest = *vertex
+				hausdorffDistance = distance
+			}
+		}
+		if hausdorffDistance <= epsilon || len(planes) >= count {
+			break
+		}
+
+		if len(hull.Faces) == 0 {
+			// Cut off the furthest vertex by the plane through the closest point.
+			addSupportingPlane(furthest.Sub(hull.ClosestPoint(furthest)))
+			continue
+		}
+
+		maxS := math.Inf(-1)
+		var cut plane
+		for i, face := range hull.Faces {
+			s := hull.Normals[i].Dot(furthest.Sub(*hull.Vertices[face[0]]))
+			if s > maxS {
+				maxS = s
+				cut = plane{
+					normal: hull.Normals[i],
+					offset: hull.Normals[i].Dot(*hull.Vertices[face[0]]),
+				}
+			}
+		}
+		planes = append(planes, cut)
+	}
+
+	simplified.Indices = nil
+	return
+}
+
+// intersectPlanes builds the convex hull of the intersection of the half-spaces below planes.
+func intersectPlanes(planes []plane, epsilon float64) *Hull {
+	points := []*mgl64.Vec3{}
+	for i := 0; i < len(planes); i += 1 {
+		for j := i + 1; j < len(planes); j += 1 {
+		candidate:
+			for k := j + 1; k < len(planes); k += 1 {
+				matrix := mgl64.Mat3FromRows(planes[i].normal, planes[j].normal, planes[k].normal)
+				if math.Abs(matrix.Det()) <= 1e-12 {
+					continue
+				}
+
+				point := matrix.Inv().Mul3x1(mgl64.Vec3{planes[i].offset, planes[j].offset, planes[k].offset})
+				tolerance := epsilon + 1e-12*point.Len()
+				for _, plane := range planes {
+					if plane.normal.Dot(point)-plane.offset > tolerance {
+						continue candidate
+					}
+				}
+				points = append(points, &point)
+			}
+		}
+	}
+
+	return NewHull(points)
+}
@@ -0,0 +1,119 @@
+package closest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestHullSimplify(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	points := []*mgl64.Vec3{}
+	for i := 0; i < 500; i += 1 {
+		point := mgl64.Vec3{
+			random.NormFloat64(),
+			random.NormFloat64(),
+			0.5 * random.NormFloat64(),
+		}.Normalize()
+		points = append(points, &point)
+	}
+	hull := NewHull(points)
+
+	for _, bound := range []Bound{Outer, Inner} {
+		lastDistance := -1.0
+		for _, count := range []int{64, 32, 16, 8} {
+			simplified, hausdorffDistance := hull.Simplify(count, bound)
+
+			var inner, outer *Hull
+			switch bound {
+			case Outer:
+				inner, outer = hull, simplified
+				normals := []mgl64.Vec3{}
+			normal:
+				for _, normal := range simplified.Normals {
+					for _, known := range normals {
+						if known.ApproxEqualThreshold(normal, 1e-9) {
+							continue normal
+						}
+					}
+					normals = append(normals, normal)
+				}
+				if len(normals) > count {
+					t.Error("Too many faces:", count, len(normals))
+				}
+			case Inner:
+				inner, outer = simplified, hull
+				if len(simplified.Vertices) > count {
+					t.Error("Too many vertices:", count, len(simplified.Vertices))
+				}
+				for i, vertex := range simplified.Vertices {
+					if points[simplified.Indices[i]] != vertex {
+						t.Error("Wrong index:", i)
+					}
+				}
+			}
+
+			correctDistance := 0.0
+			for _, vertex := range outer.Vertices {
+				closest := inner.ClosestPoint(*vertex)
+				correctDistance = math.Max(correctDistance, closest.Sub(*vertex).Len())
+			}
+			for _, vertex := range inner.Vertices {
+				if outer.ClosestPoint(*vertex).Sub(*vertex).Len() > 1e-9 {
+					t.Error("Not contained:", bound, count, vertex)
+				}
+			}
+			if hausdorffDistance != correctDistance {
+				t.Error("Wrong Hausdorff distance:", bound, count, hausdorffDistance, correctDistance)
+			}
+			if hausdorffDistance < lastDistance {
+				t.Error("More vertices or faces are worse:", bound, count, hausdorffDistance, lastDistance)
+			}
+			lastDistance = hausdorffDistance
+			t.Log(bound, count, hausdorffDistance)
+		}
+	}
+}
+
+func TestHullSimplify_Flat(t *testing.T) {
+	points := []*mgl64.Vec3{}
+	for i := 0; i < 16; i += 1 {
+		angle := 2.0 * math.Pi * float64(i) / 16.0
+		points = append(points, &mgl64.Vec3{10.0 * math.Cos(angle), 10.0 * math.Sin(angle), 1.0})
+	}
+	hull := NewHull(points)
+
+	simplified, hausdorffDistance := hull.Simplify(8, Inner)
+	if len(simplified.Vertices) != 8 {
+		t.Error("Wrong vertex count:", len(simplified.Vertices))
+	}
+	// The distance to the polygon of the coplanar points outside is the one to the closest chord.
+	correctDistance := 0.0
+	for _, point := range points {
+		distance := math.Inf(1)
+		for _, a := range simplified.Vertices {
+			for _, b := range simplified.Vertices {
+				distance = math.Min(distance, closestPointOnSegment(*point, *a, *b).Sub(*point).Len())
+			}
+		}
+		correctDistance = math.Max(correctDistance, distance)
+	}
+	if math.Abs(hausdorffDistance-correctDistance) > 1e-9 || !(hausdorffDistance > 0.7) {
+		t.Error("Wrong Hausdorff distance:", hausdorffDistance, correctDistance)
+	}
+
+	simplified, hausdorffDistance = hull.Simplify(8, Outer)
+	for _, point := range points {
+		if simplified.ClosestPoint(*point).Sub(*point).Len() > 1e-9 {
+			t.Error("Not contained:", point)
+		}
+	}
+	for _, vertex := range simplified.Vertices {
+		// The distances to the plane and to the circumscribed circle are not more than the one to the polygon.
+		if hausdorffDistance < math.Abs(vertex[2]-1.0)-1e-9 || hausdorffDistance < vertex.Vec2().Len()-10.0-1e-9 {
+			t.Error("Wrong Hausdorff distance:", hausdorffDistance, vertex)
+		}
+	}
+}
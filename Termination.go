@@ -0,0 +1,63 @@
+package closest
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Termination is the reason why the last measurement of [Measure] stopped.
+type Termination int
+
+const (
+	// Unspecified means that [Measure] has not measured yet.
+	// It is the zero value, as TERMINATION_UNSPECIFIED is in the protocol buffers.
+	Unspecified Termination = iota
+	// Converged means that GJK found no support point closer to the origin than Tolerance,
+	// so the convex hulls are separated.
+	Converged
+	// Touched means that the origin is on the Minkowski difference within Tolerance.
+	// The convex hulls are touching, or intersecting if the depth is not measured.
+	Touched
+	// Expanded means that EPA converged on the depth of the intersecting convex hulls.
+	Expanded
+	// Stalled means that GJK stopped at a degenerate simplex, or because the rounding errors
+	// kept the simplex from getting closer to the origin. The result is the best one found.
+	Stalled
+	// NoVertices means that one of the convex hulls has no vertices.
+	NoVertices
+)
+
+var terminationNames = [...]string{
+	Unspecified: "Unspecified",
+	Converged:   "Converged",
+	Touched:     "Touched",
+	Expanded:    "Expanded",
+	Stalled:     "Stalled",
+	NoVertices:  "NoVertices",
+}
+
+func (termination Termination) String() string {
+	if termination < 0 || int(termination) >= len(terminationNames) {
+		return "Termination(" + strconv.Itoa(int(termination)) + ")"
+	}
+	return terminationNames[termination]
+}
+
+// MarshalText encodes termination as its name.
+func (termination Termination) MarshalText() ([]byte, error) {
+	if termination < 0 || int(termination) >= len(terminationNames) {
+		return nil, errors.New("closest: unknown " + termination.String())
+	}
+	return []byte(terminationNames[termination]), nil
+}
+
+// UnmarshalText decodes the name of a termination.
+func (termination *Termination) UnmarshalText(text []byte) error {
+	for i, name := range terminationNames {
+		if string(text) == name {
+			*termination = Termination(i)
+			return nil
+		}
+	}
+	return errors.New("closest: unknown termination: " + strconv.Quote(string(text)))
+}
@@ -0,0 +1,35 @@
+package closest
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestMeasure_Termination(t *testing.T) {
+	box := newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 1.0, 1.0})
+	for _, testCase := range []struct {
+		other         []*mgl64.Vec3
+		isNonnegative bool
+		termination   Termination
+	}{
+		{newBox(mgl64.Vec3{2.0, 0.0, 0.0}, mgl64.Vec3{3.0, 1.0, 1.0}), false, Converged},
+		{newBox(mgl64.Vec3{1.0, 0.0, 0.0}, mgl64.Vec3{2.0, 1.0, 1.0}), false, Touched},
+		{newBox(mgl64.Vec3{0.5, 0.0, 0.0}, mgl64.Vec3{1.5, 1.0, 1.0}), false, Expanded},
+		{newBox(mgl64.Vec3{0.5, 0.0, 0.0}, mgl64.Vec3{1.5, 1.0, 1.0}), true, Touched},
+		{nil, false, NoVertices},
+	} {
+		measure := Measure{
+			ConvexHulls: [2][]*mgl64.Vec3{box, testCase.other},
+		}
+		if testCase.isNonnegative {
+			measure.MeasureNonnegativeDistance()
+		} else {
+			measure.MeasureDistance()
+		}
+
+		if measure.Termination != testCase.termination {
+			t.Error("Termination: ", measure.Termination, " Expected: ", testCase.termination, " Distance: ", measure.Distance)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package closestgrpc provides a gRPC server measuring convex hulls.
+// The service is defined in closestpb/closest.proto.
+package closestgrpc
+
+import (
+	"context"
+	"io"
+
+	"github.com/trajectoryjp/closest_go/closestgrpc/closestpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxVertexCount is used if MaxVertexCount of [Server] is zero.
+const DefaultMaxVertexCount = 10000
+
+// Server implements [closestpb.ClosestServiceServer]. The zero value is ready to use.
+// Register it by [closestpb.RegisterClosestServiceServer].
+type Server struct {
+	closestpb.UnimplementedClosestServiceServer
+
+	// MaxVertexCount limits the number of the vertices of each convex hull.
+	// If this is zero, DefaultMaxVertexCount is used.
+	MaxVertexCount int
+}
+
+// Measure measures a pair of convex hulls.
+func (server *Server) Measure(ctx context.Context, request *closestpb.MeasureRequest) (*closestpb.MeasureResult, error) {
+	measure := posedMeasure{}
+	err := measure.setHulls(request.GetHulls(), server.getMaxVertexCount())
+	if err != nil {
+		return nil, err
+	}
+	err = measure.place(request.GetPoses())
+	if err != nil {
+		return nil, err
+	}
+	if request.GetDirection() != nil {
+		measure.measure.Direction = toVec3(request.GetDirection())
+		if !isFinite(measure.measure.Direction) {
+			return nil, status.Error(codes.InvalidArgument, "direction: not finite")
+		}
+	}
+
+	return measure.measureDistance(request.GetTolerance(), request.GetNonnegative())
+}
+
+// MeasureStream measures a pair of convex hulls for each frame.
+// The stream keeps the last simplex, and starts the next frame from it.
+func (server *Server) MeasureStream(stream closestpb.ClosestService_MeasureStreamServer) error {
+	measure := posedMeasure{}
+	for isFirst := true; ; isFirst = false {
+		frame, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if isFirst || len(frame.GetHulls()) != 0 {
+			err = measure.setHulls(frame.GetHulls(), server.getMaxVertexCount())
+			if err != nil {
+				return err
+			}
+		}
+		err = measure.place(frame.GetPoses())
+		if err != nil {
+			return err
+		}
+
+		result, err := measure.measureDistance(frame.GetTolerance(), frame.GetNonnegative())
+		if err != nil {
+			return err
+		}
+		err = stream.Send(result)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (server *Server) getMaxVertexCount() int {
+	if server.MaxVertexCount == 0 {
+		return DefaultMaxVertexCount
+	}
+	return server.MaxVertexCount
+}
@@ -0,0 +1,193 @@
+package closestgrpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/trajectoryjp/closest_go"
+	"github.com/trajectoryjp/closest_go/closestgrpc/closestpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T, server *Server) closestpb.ClosestServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	closestpb.RegisterClosestServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	connection, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		connection.Close()
+	})
+
+	return closestpb.NewClosestServiceClient(connection)
+}
+
+// newTestBox returns the box from (0, 0, 0) to (1, 1, 1).
+func newTestBox() *closestpb.Hull {
+	hull := &closestpb.Hull{}
+	for i := 0; i < 8; i += 1 {
+		hull.Vertices = append(hull.Vertices, &closestpb.Vec3{
+			X: float64(i & 1),
+			Y: float64(i >> 1 & 1),
+			Z: float64(i >> 2 & 1),
+		})
+	}
+	return hull
+}
+
+func TestServer_Measure(t *testing.T) {
+	client := newTestClient(t, &Server{})
+
+	result, err := client.Measure(context.Background(), &closestpb.MeasureRequest{
+		Hulls: []*closestpb.Hull{newTestBox(), newTestBox()},
+		Poses: []*closestpb.Pose{
+			{},
+			{
+				Translation: &closestpb.Vec3{X: 3.0},
+				// 90 degrees around the z axis
+				Rotation: &closestpb.Quaternion{W: math.Sqrt2 / 2.0, Z: math.Sqrt2 / 2.0},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The rotated box is from (2, 0, 0) to (3, 1, 1).
+	if math.Abs(result.GetDistance()-1.0) > 1e-12 || result.GetTermination() != closestpb.Termination_TERMINATION_CONVERGED {
+		t.Error(result)
+	}
+	if len(result.GetPoints()) != 2 || math.Abs(result.GetPoints()[1].GetX()-2.0) > 1e-12 {
+		t.Error(result)
+	}
+
+	result, err = client.Measure(context.Background(), &closestpb.MeasureRequest{
+		Hulls: []*closestpb.Hull{newTestBox(), newTestBox()},
+		Poses: []*closestpb.Pose{{}, {Translation: &closestpb.Vec3{X: 0.75}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(result.GetDistance()+0.25) > 1e-12 || result.GetTermination() != closestpb.Termination_TERMINATION_EXPANDED {
+		t.Error(result)
+	}
+}
+
+func TestServer_Measure_InvalidArgument(t *testing.T) {
+	client := newTestClient(t, &Server{MaxVertexCount: 4})
+
+	for _, request := range []*closestpb.MeasureRequest{
+		{Hulls: []*closestpb.Hull{newTestBox()}},
+		{Hulls: []*closestpb.Hull{{}, {Vertices: []*closestpb.Vec3{{}}}}},
+		{Hulls: []*closestpb.Hull{newTestBox(), {Vertices: []*closestpb.Vec3{{}}}}},
+		{Hulls: []*closestpb.Hull{{Vertices: []*closestpb.Vec3{{X: math.NaN()}}}, {Vertices: []*closestpb.Vec3{{}}}}},
+		{Hulls: []*closestpb.Hull{{Vertices: []*closestpb.Vec3{{}}}, {Vertices: []*closestpb.Vec3{{}}}}, Tolerance: -1.0},
+		{
+			Hulls: []*closestpb.Hull{{Vertices: []*closestpb.Vec3{{}}}, {Vertices: []*closestpb.Vec3{{}}}},
+			Poses: []*closestpb.Pose{{}, {Rotation: &closestpb.Quaternion{}}},
+		},
+		{
+			Hulls:     []*closestpb.Hull{{Vertices: []*closestpb.Vec3{{}}}, {Vertices: []*closestpb.Vec3{{}}}},
+			Direction: &closestpb.Vec3{X: math.Inf(1)},
+		},
+	} {
+		_, err := client.Measure(context.Background(), request)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Error(request, ": ", err)
+		}
+	}
+}
+
+func TestServer_MeasureStream(t *testing.T) {
+	client := newTestClient(t, &Server{})
+
+	stream, err := client.MeasureStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seedCount := 0
+	for i := 0; i < 20; i += 1 {
+		// The second box comes closer frame by frame.
+		x := 3.0 - 0.05*float64(i)
+		frame := &closestpb.Frame{
+			Poses: []*closestpb.Pose{{}, {Translation: &closestpb.Vec3{X: x, Y: 0.2, Z: 0.1}}},
+		}
+		if i == 0 {
+			frame.Hulls = []*closestpb.Hull{newTestBox(), newTestBox()}
+		}
+		err = stream.Send(frame)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(result.GetDistance()-(x-1.0)) > 1e-12 {
+			t.Error("Frame ", i, ": ", result)
+		}
+		seedCount += int(result.GetSeedCount())
+	}
+	if seedCount == 0 {
+		t.Error("The stream does not warm start.")
+	}
+
+	err = stream.CloseSend()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if err == nil {
+		t.Error("The stream is not closed.")
+	}
+}
+
+func TestServer_MeasureStream_NoHulls(t *testing.T) {
+	client := newTestClient(t, &Server{})
+
+	stream, err := client.MeasureStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&closestpb.Frame{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error(err)
+	}
+}
+
+func TestToPBTermination(t *testing.T) {
+	for _, termination := range []closest.Termination{
+		closest.Unspecified,
+		closest.Converged,
+		closest.Touched,
+		closest.Expanded,
+		closest.Stalled,
+		closest.NoVertices,
+	} {
+		if int32(toPBTermination(termination)) != int32(termination) {
+			t.Error("Termination: ", termination, " Protocol buffers: ", toPBTermination(termination))
+		}
+	}
+}
@@ -0,0 +1,733 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: closest.proto
+
+package closestpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Termination is the reason why a measurement stopped.
+type Termination int32
+
+const (
+	Termination_TERMINATION_UNSPECIFIED Termination = 0
+	Termination_TERMINATION_CONVERGED   Termination = 1
+	Termination_TERMINATION_TOUCHED     Termination = 2
+	Termination_TERMINATION_EXPANDED    Termination = 3
+	Termination_TERMINATION_STALLED     Termination = 4
+	Termination_TERMINATION_NO_VERTICES Termination = 5
+)
+
+// Enum value maps for Termination.
+var (
+	Termination_name = map[int32]string{
+		0: "TERMINATION_UNSPECIFIED",
+		1: "TERMINATION_CONVERGED",
+		2: "TERMINATION_TOUCHED",
+		3: "TERMINATION_EXPANDED",
+		4: "TERMINATION_STALLED",
+		5: "TERMINATION_NO_VERTICES",
+	}
+	Termination_value = map[string]int32{
+		"TERMINATION_UNSPECIFIED": 0,
+		"TERMINATION_CONVERGED":   1,
+		"TERMINATION_TOUCHED":     2,
+		"TERMINATION_EXPANDED":    3,
+		"TERMINATION_STALLED":     4,
+		"TERMINATION_NO_VERTICES": 5,
+	}
+)
+
+func (x Termination) Enum() *Termination {
+	p := new(Termination)
+	*p = x
+	return p
+}
+
+func (x Termination) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Termination) Descriptor() protoreflect.EnumDescriptor {
+	return file_closest_proto_enumTypes[0].Descriptor()
+}
+
+func (Termination) Type() protoreflect.EnumType {
+	return &file_closest_proto_enumTypes[0]
+}
+
+func (x Termination) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Termination.Descriptor instead.
+func (Termination) EnumDescriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{0}
+}
+
+type Vec3 struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             float64                `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z             float64                `protobuf:"fixed64,3,opt,name=z,proto3" json:"z,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vec3) Reset() {
+	*x = Vec3{}
+	mi := &file_closest_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vec3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vec3) ProtoMessage() {}
+
+func (x *Vec3) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vec3.ProtoReflect.Descriptor instead.
+func (*Vec3) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vec3) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Vec3) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Vec3) GetZ() float64 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+type Quaternion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	W             float64                `protobuf:"fixed64,1,opt,name=w,proto3" json:"w,omitempty"`
+	X             float64                `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             float64                `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	Z             float64                `protobuf:"fixed64,4,opt,name=z,proto3" json:"z,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quaternion) Reset() {
+	*x = Quaternion{}
+	mi := &file_closest_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quaternion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quaternion) ProtoMessage() {}
+
+func (x *Quaternion) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quaternion.ProtoReflect.Descriptor instead.
+func (*Quaternion) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Quaternion) GetW() float64 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+func (x *Quaternion) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Quaternion) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Quaternion) GetZ() float64 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+// Hull is a convex hull by its vertices in its own frame.
+type Hull struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vertices      []*Vec3                `protobuf:"bytes,1,rep,name=vertices,proto3" json:"vertices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hull) Reset() {
+	*x = Hull{}
+	mi := &file_closest_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hull) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hull) ProtoMessage() {}
+
+func (x *Hull) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hull.ProtoReflect.Descriptor instead.
+func (*Hull) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Hull) GetVertices() []*Vec3 {
+	if x != nil {
+		return x.Vertices
+	}
+	return nil
+}
+
+// Pose places a hull by rotating it and then translating it.
+// If rotation is not set, it is not rotated.
+type Pose struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Translation   *Vec3                  `protobuf:"bytes,1,opt,name=translation,proto3" json:"translation,omitempty"`
+	Rotation      *Quaternion            `protobuf:"bytes,2,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pose) Reset() {
+	*x = Pose{}
+	mi := &file_closest_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pose) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pose) ProtoMessage() {}
+
+func (x *Pose) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pose.ProtoReflect.Descriptor instead.
+func (*Pose) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Pose) GetTranslation() *Vec3 {
+	if x != nil {
+		return x.Translation
+	}
+	return nil
+}
+
+func (x *Pose) GetRotation() *Quaternion {
+	if x != nil {
+		return x.Rotation
+	}
+	return nil
+}
+
+type MeasureRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hulls are the two convex hulls.
+	Hulls []*Hull `protobuf:"bytes,1,rep,name=hulls,proto3" json:"hulls,omitempty"`
+	// poses are the poses of hulls. If empty, hulls are not moved.
+	Poses []*Pose `protobuf:"bytes,2,rep,name=poses,proto3" json:"poses,omitempty"`
+	// tolerance is relative to the magnitude of the coordinates. If zero, the default is used.
+	Tolerance float64 `protobuf:"fixed64,3,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	// nonnegative measures the nonnegative distance instead of the depth.
+	Nonnegative bool `protobuf:"varint,4,opt,name=nonnegative,proto3" json:"nonnegative,omitempty"`
+	// direction is the initial direction from the first convex hull to the second one.
+	Direction     *Vec3 `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeasureRequest) Reset() {
+	*x = MeasureRequest{}
+	mi := &file_closest_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeasureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeasureRequest) ProtoMessage() {}
+
+func (x *MeasureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeasureRequest.ProtoReflect.Descriptor instead.
+func (*MeasureRequest) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MeasureRequest) GetHulls() []*Hull {
+	if x != nil {
+		return x.Hulls
+	}
+	return nil
+}
+
+func (x *MeasureRequest) GetPoses() []*Pose {
+	if x != nil {
+		return x.Poses
+	}
+	return nil
+}
+
+func (x *MeasureRequest) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+func (x *MeasureRequest) GetNonnegative() bool {
+	if x != nil {
+		return x.Nonnegative
+	}
+	return false
+}
+
+func (x *MeasureRequest) GetDirection() *Vec3 {
+	if x != nil {
+		return x.Direction
+	}
+	return nil
+}
+
+type Frame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hulls replace the two convex hulls of the stream. They must be set in the first frame,
+	// and can be omitted afterwards.
+	Hulls []*Hull `protobuf:"bytes,1,rep,name=hulls,proto3" json:"hulls,omitempty"`
+	// poses are the poses of the hulls in this frame. If empty, the hulls are not moved.
+	Poses []*Pose `protobuf:"bytes,2,rep,name=poses,proto3" json:"poses,omitempty"`
+	// tolerance is relative to the magnitude of the coordinates. If zero, the default is used.
+	Tolerance float64 `protobuf:"fixed64,3,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+	// nonnegative measures the nonnegative distance instead of the depth.
+	Nonnegative   bool `protobuf:"varint,4,opt,name=nonnegative,proto3" json:"nonnegative,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Frame) Reset() {
+	*x = Frame{}
+	mi := &file_closest_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Frame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Frame) ProtoMessage() {}
+
+func (x *Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Frame.ProtoReflect.Descriptor instead.
+func (*Frame) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Frame) GetHulls() []*Hull {
+	if x != nil {
+		return x.Hulls
+	}
+	return nil
+}
+
+func (x *Frame) GetPoses() []*Pose {
+	if x != nil {
+		return x.Poses
+	}
+	return nil
+}
+
+func (x *Frame) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+func (x *Frame) GetNonnegative() bool {
+	if x != nil {
+		return x.Nonnegative
+	}
+	return false
+}
+
+// Indices are the indices of the vertices of a hull.
+type Indices struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Indices       []int32                `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Indices) Reset() {
+	*x = Indices{}
+	mi := &file_closest_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Indices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Indices) ProtoMessage() {}
+
+func (x *Indices) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Indices.ProtoReflect.Descriptor instead.
+func (*Indices) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Indices) GetIndices() []int32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+type MeasureResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// distance is the distance if it is non-negative, or the depth if it is negative.
+	Distance float64 `protobuf:"fixed64,1,opt,name=distance,proto3" json:"distance,omitempty"`
+	// direction is from the first convex hull to the second one.
+	Direction *Vec3 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	// points are the closest points on each convex hull.
+	Points []*Vec3 `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	// ons are the sorted indices of the vertices making up the closest points on each convex hull.
+	Ons []*Indices `protobuf:"bytes,4,rep,name=ons,proto3" json:"ons,omitempty"`
+	// iteration_count is the number of the support points GJK calculated.
+	IterationCount int32 `protobuf:"varint,5,opt,name=iteration_count,json=iterationCount,proto3" json:"iteration_count,omitempty"`
+	// seed_count is the number of the vertices of the last simplex reused.
+	SeedCount     int32       `protobuf:"varint,6,opt,name=seed_count,json=seedCount,proto3" json:"seed_count,omitempty"`
+	Termination   Termination `protobuf:"varint,7,opt,name=termination,proto3,enum=closest.v1.Termination" json:"termination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeasureResult) Reset() {
+	*x = MeasureResult{}
+	mi := &file_closest_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeasureResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeasureResult) ProtoMessage() {}
+
+func (x *MeasureResult) ProtoReflect() protoreflect.Message {
+	mi := &file_closest_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeasureResult.ProtoReflect.Descriptor instead.
+func (*MeasureResult) Descriptor() ([]byte, []int) {
+	return file_closest_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MeasureResult) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *MeasureResult) GetDirection() *Vec3 {
+	if x != nil {
+		return x.Direction
+	}
+	return nil
+}
+
+func (x *MeasureResult) GetPoints() []*Vec3 {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *MeasureResult) GetOns() []*Indices {
+	if x != nil {
+		return x.Ons
+	}
+	return nil
+}
+
+func (x *MeasureResult) GetIterationCount() int32 {
+	if x != nil {
+		return x.IterationCount
+	}
+	return 0
+}
+
+func (x *MeasureResult) GetSeedCount() int32 {
+	if x != nil {
+		return x.SeedCount
+	}
+	return 0
+}
+
+func (x *MeasureResult) GetTermination() Termination {
+	if x != nil {
+		return x.Termination
+	}
+	return Termination_TERMINATION_UNSPECIFIED
+}
+
+var File_closest_proto protoreflect.FileDescriptor
+
+const file_closest_proto_rawDesc = "" +
+	"\n" +
+	"\rclosest.proto\x12\n" +
+	"closest.v1\"0\n" +
+	"\x04Vec3\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x01R\x01y\x12\f\n" +
+	"\x01z\x18\x03 \x01(\x01R\x01z\"D\n" +
+	"\n" +
+	"Quaternion\x12\f\n" +
+	"\x01w\x18\x01 \x01(\x01R\x01w\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x01R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x01R\x01y\x12\f\n" +
+	"\x01z\x18\x04 \x01(\x01R\x01z\"4\n" +
+	"\x04Hull\x12,\n" +
+	"\bvertices\x18\x01 \x03(\v2\x10.closest.v1.Vec3R\bvertices\"n\n" +
+	"\x04Pose\x122\n" +
+	"\vtranslation\x18\x01 \x01(\v2\x10.closest.v1.Vec3R\vtranslation\x122\n" +
+	"\brotation\x18\x02 \x01(\v2\x16.closest.v1.QuaternionR\brotation\"\xd0\x01\n" +
+	"\x0eMeasureRequest\x12&\n" +
+	"\x05hulls\x18\x01 \x03(\v2\x10.closest.v1.HullR\x05hulls\x12&\n" +
+	"\x05poses\x18\x02 \x03(\v2\x10.closest.v1.PoseR\x05poses\x12\x1c\n" +
+	"\ttolerance\x18\x03 \x01(\x01R\ttolerance\x12 \n" +
+	"\vnonnegative\x18\x04 \x01(\bR\vnonnegative\x12.\n" +
+	"\tdirection\x18\x05 \x01(\v2\x10.closest.v1.Vec3R\tdirection\"\x97\x01\n" +
+	"\x05Frame\x12&\n" +
+	"\x05hulls\x18\x01 \x03(\v2\x10.closest.v1.HullR\x05hulls\x12&\n" +
+	"\x05poses\x18\x02 \x03(\v2\x10.closest.v1.PoseR\x05poses\x12\x1c\n" +
+	"\ttolerance\x18\x03 \x01(\x01R\ttolerance\x12 \n" +
+	"\vnonnegative\x18\x04 \x01(\bR\vnonnegative\"#\n" +
+	"\aIndices\x12\x18\n" +
+	"\aindices\x18\x01 \x03(\x05R\aindices\"\xaf\x02\n" +
+	"\rMeasureResult\x12\x1a\n" +
+	"\bdistance\x18\x01 \x01(\x01R\bdistance\x12.\n" +
+	"\tdirection\x18\x02 \x01(\v2\x10.closest.v1.Vec3R\tdirection\x12(\n" +
+	"\x06points\x18\x03 \x03(\v2\x10.closest.v1.Vec3R\x06points\x12%\n" +
+	"\x03ons\x18\x04 \x03(\v2\x13.closest.v1.IndicesR\x03ons\x12'\n" +
+	"\x0fiteration_count\x18\x05 \x01(\x05R\x0eiterationCount\x12\x1d\n" +
+	"\n" +
+	"seed_count\x18\x06 \x01(\x05R\tseedCount\x129\n" +
+	"\vtermination\x18\a \x01(\x0e2\x17.closest.v1.TerminationR\vtermination*\xae\x01\n" +
+	"\vTermination\x12\x1b\n" +
+	"\x17TERMINATION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15TERMINATION_CONVERGED\x10\x01\x12\x17\n" +
+	"\x13TERMINATION_TOUCHED\x10\x02\x12\x18\n" +
+	"\x14TERMINATION_EXPANDED\x10\x03\x12\x17\n" +
+	"\x13TERMINATION_STALLED\x10\x04\x12\x1b\n" +
+	"\x17TERMINATION_NO_VERTICES\x10\x052\x95\x01\n" +
+	"\x0eClosestService\x12@\n" +
+	"\aMeasure\x12\x1a.closest.v1.MeasureRequest\x1a\x19.closest.v1.MeasureResult\x12A\n" +
+	"\rMeasureStream\x12\x11.closest.v1.Frame\x1a\x19.closest.v1.MeasureResult(\x010\x01B:Z8github.com/trajectoryjp/closest_go/closestgrpc/closestpbb\x06proto3"
+
+var (
+	file_closest_proto_rawDescOnce sync.Once
+	file_closest_proto_rawDescData []byte
+)
+
+func file_closest_proto_rawDescGZIP() []byte {
+	file_closest_proto_rawDescOnce.Do(func() {
+		file_closest_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_closest_proto_rawDesc), len(file_closest_proto_rawDesc)))
+	})
+	return file_closest_proto_rawDescData
+}
+
+var file_closest_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_closest_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_closest_proto_goTypes = []any{
+	(Termination)(0),       // 0: closest.v1.Termination
+	(*Vec3)(nil),           // 1: closest.v1.Vec3
+	(*Quaternion)(nil),     // 2: closest.v1.Quaternion
+	(*Hull)(nil),           // 3: closest.v1.Hull
+	(*Pose)(nil),           // 4: closest.v1.Pose
+	(*MeasureRequest)(nil), // 5: closest.v1.MeasureRequest
+	(*Frame)(nil),          // 6: closest.v1.Frame
+	(*Indices)(nil),        // 7: closest.v1.Indices
+	(*MeasureResult)(nil),  // 8: closest.v1.MeasureResult
+}
+var file_closest_proto_depIdxs = []int32{
+	1,  // 0: closest.v1.Hull.vertices:type_name -> closest.v1.Vec3
+	1,  // 1: closest.v1.Pose.translation:type_name -> closest.v1.Vec3
+	2,  // 2: closest.v1.Pose.rotation:type_name -> closest.v1.Quaternion
+	3,  // 3: closest.v1.MeasureRequest.hulls:type_name -> closest.v1.Hull
+	4,  // 4: closest.v1.MeasureRequest.poses:type_name -> closest.v1.Pose
+	1,  // 5: closest.v1.MeasureRequest.direction:type_name -> closest.v1.Vec3
+	3,  // 6: closest.v1.Frame.hulls:type_name -> closest.v1.Hull
+	4,  // 7: closest.v1.Frame.poses:type_name -> closest.v1.Pose
+	1,  // 8: closest.v1.MeasureResult.direction:type_name -> closest.v1.Vec3
+	1,  // 9: closest.v1.MeasureResult.points:type_name -> closest.v1.Vec3
+	7,  // 10: closest.v1.MeasureResult.ons:type_name -> closest.v1.Indices
+	0,  // 11: closest.v1.MeasureResult.termination:type_name -> closest.v1.Termination
+	5,  // 12: closest.v1.ClosestService.Measure:input_type -> closest.v1.MeasureRequest
+	6,  // 13: closest.v1.ClosestService.MeasureStream:input_type -> closest.v1.Frame
+	8,  // 14: closest.v1.ClosestService.Measure:output_type -> closest.v1.MeasureResult
+	8,  // 15: closest.v1.ClosestService.MeasureStream:output_type -> closest.v1.MeasureResult
+	14, // [14:16] is the sub-list for method output_type
+	12, // [12:14] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_closest_proto_init() }
+func file_closest_proto_init() {
+	if File_closest_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_closest_proto_rawDesc), len(file_closest_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_closest_proto_goTypes,
+		DependencyIndexes: file_closest_proto_depIdxs,
+		EnumInfos:         file_closest_proto_enumTypes,
+		MessageInfos:      file_closest_proto_msgTypes,
+	}.Build()
+	File_closest_proto = out.File
+	file_closest_proto_goTypes = nil
+	file_closest_proto_depIdxs = nil
+}
@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: closest.proto
+
+package closestpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ClosestService_Measure_FullMethodName       = "/closest.v1.ClosestService/Measure"
+	ClosestService_MeasureStream_FullMethodName = "/closest.v1.ClosestService/MeasureStream"
+)
+
+// ClosestServiceClient is the client API for ClosestService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ClosestService measures the distance or the depth between two convex hulls.
+type ClosestServiceClient interface {
+	// Measure measures a pair of convex hulls.
+	Measure(ctx context.Context, in *MeasureRequest, opts ...grpc.CallOption) (*MeasureResult, error)
+	// MeasureStream measures a pair of convex hulls moving frame by frame.
+	// A result is sent for each frame. The stream keeps the last simplex,
+	// so the frames where the convex hulls move a little are measured fast.
+	MeasureStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Frame, MeasureResult], error)
+}
+
+type closestServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClosestServiceClient(cc grpc.ClientConnInterface) ClosestServiceClient {
+	return &closestServiceClient{cc}
+}
+
+func (c *closestServiceClient) Measure(ctx context.Context, in *MeasureRequest, opts ...grpc.CallOption) (*MeasureResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeasureResult)
+	err := c.cc.Invoke(ctx, ClosestService_Measure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *closestServiceClient) MeasureStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Frame, MeasureResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ClosestService_ServiceDesc.Streams[0], ClosestService_MeasureStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Frame, MeasureResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ClosestService_MeasureStreamClient = grpc.BidiStreamingClient[Frame, MeasureResult]
+
+// ClosestServiceServer is the server API for ClosestService service.
+// All implementations must embed UnimplementedClosestServiceServer
+// for forward compatibility.
+//
+// ClosestService measures the distance or the depth between two convex hulls.
+type ClosestServiceServer interface {
+	// Measure measures a pair of convex hulls.
+	Measure(context.Context, *MeasureRequest) (*MeasureResult, error)
+	// MeasureStream measures a pair of convex hulls moving frame by frame.
+	// A result is sent for each frame. The stream keeps the last simplex,
+	// so the frames where the convex hulls move a little are measured fast.
+	MeasureStream(grpc.BidiStreamingServer[Frame, MeasureResult]) error
+	mustEmbedUnimplementedClosestServiceServer()
+}
+
+// UnimplementedClosestServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClosestServiceServer struct{}
+
+func (UnimplementedClosestServiceServer) Measure(context.Context, *MeasureRequest) (*MeasureResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Measure not implemented")
+}
+func (UnimplementedClosestServiceServer) MeasureStream(grpc.BidiStreamingServer[Frame, MeasureResult]) error {
+	return status.Errorf(codes.Unimplemented, "method MeasureStream not implemented")
+}
+func (UnimplementedClosestServiceServer) mustEmbedUnimplementedClosestServiceServer() {}
+func (UnimplementedClosestServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeClosestServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClosestServiceServer will
+// result in compilation errors.
+type UnsafeClosestServiceServer interface {
+	mustEmbedUnimplementedClosestServiceServer()
+}
+
+func RegisterClosestServiceServer(s grpc.ServiceRegistrar, srv ClosestServiceServer) {
+	// If the following call pancis, it indicates UnimplementedClosestServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ClosestService_ServiceDesc, srv)
+}
+
+func _ClosestService_Measure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeasureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClosestServiceServer).Measure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClosestService_Measure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClosestServiceServer).Measure(ctx, req.(*MeasureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClosestService_MeasureStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ClosestServiceServer).MeasureStream(&grpc.GenericServerStream[Frame, MeasureResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ClosestService_MeasureStreamServer = grpc.BidiStreamingServer[Frame, MeasureResult]
+
+// ClosestService_ServiceDesc is the grpc.ServiceDesc for ClosestService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClosestService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "closest.v1.ClosestService",
+	HandlerType: (*ClosestServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Measure",
+			Handler:    _ClosestService_Measure_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MeasureStream",
+			Handler:       _ClosestService_MeasureStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "closest.proto",
+}
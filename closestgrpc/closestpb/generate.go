@@ -0,0 +1,4 @@
+// Package closestpb is the protocol buffers of the closest service generated from closest.proto.
+package closestpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative closest.proto
@@ -0,0 +1,49 @@
+// Command closest-grpc-server serves the measurements of convex hulls over gRPC.
+// See closestpb/closest.proto for the service.
+//
+// Usage:
+//
+//	closest-grpc-server [flags]
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/trajectoryjp/closest_go/closestgrpc"
+	"github.com/trajectoryjp/closest_go/closestgrpc/closestpb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	address := flag.String("address", "localhost:50051", "The address to listen on.")
+	maxVertexCount := flag.Int("max-vertices", closestgrpc.DefaultMaxVertexCount, "The maximum number of the vertices of each convex hull.")
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", *address)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	closestpb.RegisterClosestServiceServer(server, &closestgrpc.Server{
+		MaxVertexCount: *maxVertexCount,
+	})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		server.GracefulStop()
+	}()
+
+	log.Print("Listening on ", listener.Addr())
+	err = server.Serve(listener)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
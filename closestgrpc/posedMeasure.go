@@ -0,0 +1,161 @@
+package closestgrpc
+
+import (
+	"log"
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+	"github.com/trajectoryjp/closest_go/closestgrpc/closestpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// posedMeasure measures the convex hulls in their own frames placed by the poses.
+// ConvexHulls of measure point to the placed vertices, which are updated in place,
+// so the warm start works between the poses.
+type posedMeasure struct {
+	locals  [2][]mgl64.Vec3
+	placeds [2][]mgl64.Vec3
+	measure closest.Measure
+}
+
+// setHulls replaces the convex hulls with hulls.
+func (posedMeasure *posedMeasure) setHulls(hulls []*closestpb.Hull, maxVertexCount int) error {
+	if len(hulls) != 2 {
+		return status.Errorf(codes.InvalidArgument, "hulls: 2 are required, but %d are given", len(hulls))
+	}
+	for i, hull := range hulls {
+		vertices := hull.GetVertices()
+		if len(vertices) == 0 {
+			return status.Errorf(codes.InvalidArgument, "hulls[%d]: no vertices", i)
+		}
+		if len(vertices) > maxVertexCount {
+			return status.Errorf(codes.InvalidArgument, "hulls[%d]: more than %d vertices", i, maxVertexCount)
+		}
+
+		posedMeasure.locals[i] = make([]mgl64.Vec3, len(vertices))
+		posedMeasure.placeds[i] = make([]mgl64.Vec3, len(vertices))
+		posedMeasure.measure.ConvexHulls[i] = make([]*mgl64.Vec3, len(vertices))
+		for j, vertex := range vertices {
+			posedMeasure.locals[i][j] = toVec3(vertex)
+			if !isFinite(posedMeasure.locals[i][j]) {
+				return status.Errorf(codes.InvalidArgument, "hulls[%d].vertices[%d]: not finite", i, j)
+			}
+			posedMeasure.placeds[i][j] = posedMeasure.locals[i][j]
+			posedMeasure.measure.ConvexHulls[i][j] = &posedMeasure.placeds[i][j]
+		}
+	}
+	posedMeasure.measure.WarmStartsSimplex = true
+
+	return nil
+}
+
+// place places the convex hulls by poses. If poses are empty, the convex hulls are not moved.
+func (posedMeasure *posedMeasure) place(poses []*closestpb.Pose) error {
+	if len(poses) == 0 {
+		for i := range posedMeasure.locals {
+			copy(posedMeasure.placeds[i], posedMeasure.locals[i])
+		}
+		return nil
+	}
+	if len(poses) != 2 {
+		return status.Errorf(codes.InvalidArgument, "poses: 2 are required, but %d are given", len(poses))
+	}
+
+	for i, pose := range poses {
+		translation := toVec3(pose.GetTranslation())
+		rotation := mgl64.QuatIdent()
+		if pose.GetRotation() != nil {
+			rotation = mgl64.Quat{
+				W: pose.GetRotation().GetW(),
+				V: mgl64.Vec3{pose.GetRotation().GetX(), pose.GetRotation().GetY(), pose.GetRotation().GetZ()},
+			}
+		}
+		length := rotation.Len()
+		if !isFinite(translation) || !(length > 0.0) || math.IsInf(length, 0) {
+			return status.Errorf(codes.InvalidArgument, "poses[%d]: invalid", i)
+		}
+		rotation = rotation.Scale(1.0 / length)
+
+		for j, local := range posedMeasure.locals[i] {
+			posedMeasure.placeds[i][j] = rotation.Rotate(local).Add(translation)
+		}
+	}
+
+	return nil
+}
+
+func (posedMeasure *posedMeasure) measureDistance(tolerance float64, isNonnegative bool) (*closestpb.MeasureResult, error) {
+	if !(tolerance >= 0.0 && tolerance < 1.0) {
+		return nil, status.Error(codes.InvalidArgument, "tolerance: out of [0, 1)")
+	}
+
+	measure := &posedMeasure.measure
+	measure.Tolerance = tolerance
+	if isNonnegative {
+		measure.MeasureNonnegativeDistance()
+	} else {
+		measure.MeasureDistance()
+	}
+
+	result := &closestpb.MeasureResult{
+		Distance:       measure.Distance,
+		Direction:      toPBVec3(measure.Direction),
+		IterationCount: int32(measure.IterationCount),
+		SeedCount:      int32(measure.SeedCount),
+		Termination:    toPBTermination(measure.Termination),
+	}
+	for i := range measure.Points {
+		result.Points = append(result.Points, toPBVec3(measure.Points[i]))
+
+		indices := &closestpb.Indices{}
+		for index := range measure.Ons[i] {
+			indices.Indices = append(indices.Indices, int32(index))
+		}
+		sort.Slice(indices.Indices, func(j int, k int) bool {
+			return indices.Indices[j] < indices.Indices[k]
+		})
+		result.Ons = append(result.Ons, indices)
+	}
+
+	return result, nil
+}
+
+func toVec3(vector *closestpb.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{vector.GetX(), vector.GetY(), vector.GetZ()}
+}
+
+func toPBVec3(vector mgl64.Vec3) *closestpb.Vec3 {
+	return &closestpb.Vec3{X: vector[0], Y: vector[1], Z: vector[2]}
+}
+
+func toPBTermination(termination closest.Termination) closestpb.Termination {
+	switch termination {
+	case closest.Unspecified:
+		return closestpb.Termination_TERMINATION_UNSPECIFIED
+	case closest.Converged:
+		return closestpb.Termination_TERMINATION_CONVERGED
+	case closest.Touched:
+		return closestpb.Termination_TERMINATION_TOUCHED
+	case closest.Expanded:
+		return closestpb.Termination_TERMINATION_EXPANDED
+	case closest.Stalled:
+		return closestpb.Termination_TERMINATION_STALLED
+	case closest.NoVertices:
+		return closestpb.Termination_TERMINATION_NO_VERTICES
+	default:
+		log.Panic("Must not come here!")
+		return closestpb.Termination_TERMINATION_UNSPECIFIED
+	}
+}
+
+func isFinite(vector mgl64.Vec3) bool {
+	for _, component := range vector {
+		if math.IsNaN(component) || math.IsInf(component, 0) {
+			return false
+		}
+	}
+	return true
+}
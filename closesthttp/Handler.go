@@ -0,0 +1,234 @@
+// Package closesthttp provides an [http.Handler] measuring convex hulls over JSON,
+// for the services not written in Go.
+//
+// The endpoints are:
+//
+//   - POST /measure measures the distance or the depth between two convex hulls.
+//   - POST /intersects tests whether two convex hulls intersect.
+//   - POST /batch measures the pairs of named convex hulls.
+//   - GET /metrics writes the metrics in the Prometheus text format.
+//
+// Mount it with [http.StripPrefix] to serve it under a path.
+package closesthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is used if Timeout of [Handler] is zero.
+const DefaultTimeout = 10 * time.Second
+
+// DefaultMaxVertexCount is used if MaxVertexCount of [Handler] is zero.
+const DefaultMaxVertexCount = 10000
+
+// DefaultMaxPairCount is used if MaxPairCount of [Handler] is zero.
+const DefaultMaxPairCount = 10000
+
+// DefaultMaxBodySize is used if MaxBodySize of [Handler] is zero.
+const DefaultMaxBodySize = 64 << 20
+
+// Handler serves the measurements over JSON. The zero value is ready to use.
+// The fields must not be changed after the first request.
+type Handler struct {
+	// Timeout limits the time to measure for each request.
+	// If this is zero, DefaultTimeout is used.
+	Timeout time.Duration
+	// MaxVertexCount limits the number of the vertices of each convex hull.
+	// If this is zero, DefaultMaxVertexCount is used.
+	MaxVertexCount int
+	// MaxPairCount limits the number of the pairs of a batch.
+	// If this is zero, DefaultMaxPairCount is used.
+	MaxPairCount int
+	// MaxBodySize limits the size of the body of each request in bytes.
+	// If this is zero, DefaultMaxBodySize is used.
+	MaxBodySize int64
+	// MaxConcurrency limits the number of the requests measuring at the same time,
+	// including the ones which timed out but are still measuring in the background.
+	// If this is zero, runtime.GOMAXPROCS(0) is used.
+	MaxConcurrency int
+
+	once    sync.Once
+	mux     *http.ServeMux
+	slots   chan struct{}
+	metrics metrics
+}
+
+// errPanicked wraps the value of the panic while measuring.
+var errPanicked = errors.New("panicked while measuring")
+
+// requestBody is the body of a request to an endpoint.
+type requestBody interface {
+	// validate returns the error describing the invalid request, or nil.
+	validate(handler *Handler) error
+	// respond returns the body of the response. It stops with ctx.Err() if ctx is done.
+	respond(ctx context.Context, metrics *metrics) (any, error)
+}
+
+// errorResponse is the body of the response of an error.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP serves the endpoints.
+func (handler *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	handler.once.Do(func() {
+		handler.slots = make(chan struct{}, handler.getMaxConcurrency())
+		handler.mux = http.NewServeMux()
+		handler.mux.Handle("/measure", handler.newEndpoint("measure", func() requestBody {
+			return &measureRequest{}
+		}))
+		handler.mux.Handle("/intersects", handler.newEndpoint("intersects", func() requestBody {
+			return &intersectsRequest{}
+		}))
+		handler.mux.Handle("/batch", handler.newEndpoint("batch", func() requestBody {
+			return &batchRequest{}
+		}))
+		handler.mux.HandleFunc("/metrics", handler.serveMetrics)
+	})
+
+	handler.mux.ServeHTTP(writer, request)
+}
+
+// newEndpoint returns the handler of the endpoint decoding the requests made by newRequest.
+func (handler *Handler) newEndpoint(name string, newRequest func() requestBody) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		start := time.Now()
+		code := handler.serveEndpoint(writer, request, newRequest())
+		handler.metrics.observeRequest(name, code, time.Since(start))
+	}
+}
+
+// serveEndpoint decodes and validates the body into theRequest, and writes its response.
+// It returns the status code.
+func (handler *Handler) serveEndpoint(writer http.ResponseWriter, request *http.Request, theRequest requestBody) int {
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		return writeError(writer, http.StatusMethodNotAllowed, "method not allowed")
+	}
+
+	// The body is read here, because it must not be read after ServeHTTP returns.
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, handler.getMaxBodySize()))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(theRequest)
+	if err != nil {
+		// http.MaxBytesError is not available before Go 1.19, but the message is the same.
+		if err.Error() == "http: request body too large" {
+			return writeError(writer, http.StatusRequestEntityTooLarge, err.Error())
+		}
+		return writeError(writer, http.StatusBadRequest, "invalid JSON: "+err.Error())
+	}
+	if decoder.More() {
+		return writeError(writer, http.StatusBadRequest, "invalid JSON: trailing data")
+	}
+	err = theRequest.validate(handler)
+	if err != nil {
+		return writeError(writer, http.StatusBadRequest, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), handler.getTimeout())
+	defer cancel()
+
+	// A measurement cannot be stopped, so it keeps its slot until it finishes even after the timeout.
+	select {
+	case handler.slots <- struct{}{}:
+	case <-ctx.Done():
+		return writeError(writer, http.StatusServiceUnavailable, ctx.Err().Error())
+	}
+
+	type outcome struct {
+		response any
+		err      error
+	}
+	outcomes := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			// A panic must not crash the server nor keep the slot.
+			if r := recover(); r != nil {
+				outcomes <- outcome{nil, fmt.Errorf("%w: %v", errPanicked, r)}
+			}
+			<-handler.slots
+		}()
+		response, err := theRequest.respond(ctx, &handler.metrics)
+		outcomes <- outcome{response, err}
+	}()
+
+	select {
+	case theOutcome := <-outcomes:
+		if errors.Is(theOutcome.err, errPanicked) {
+			return writeError(writer, http.StatusInternalServerError, theOutcome.err.Error())
+		}
+		if theOutcome.err != nil {
+			return writeError(writer, http.StatusServiceUnavailable, theOutcome.err.Error())
+		}
+		return writeJSON(writer, http.StatusOK, theOutcome.response)
+	case <-ctx.Done():
+		// The measurement in progress is left to finish in the background with its slot.
+		return writeError(writer, http.StatusServiceUnavailable, ctx.Err().Error())
+	}
+}
+
+func (handler *Handler) serveMetrics(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writer.Header().Set("Allow", http.MethodGet)
+		writeError(writer, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	handler.metrics.write(writer)
+}
+
+func (handler *Handler) getTimeout() time.Duration {
+	if handler.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return handler.Timeout
+}
+
+func (handler *Handler) getMaxVertexCount() int {
+	if handler.MaxVertexCount == 0 {
+		return DefaultMaxVertexCount
+	}
+	return handler.MaxVertexCount
+}
+
+func (handler *Handler) getMaxPairCount() int {
+	if handler.MaxPairCount == 0 {
+		return DefaultMaxPairCount
+	}
+	return handler.MaxPairCount
+}
+
+func (handler *Handler) getMaxBodySize() int64 {
+	if handler.MaxBodySize == 0 {
+		return DefaultMaxBodySize
+	}
+	return handler.MaxBodySize
+}
+
+func (handler *Handler) getMaxConcurrency() int {
+	if handler.MaxConcurrency == 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return handler.MaxConcurrency
+}
+
+func writeJSON(writer http.ResponseWriter, code int, response any) int {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(code)
+	json.NewEncoder(writer).Encode(response)
+	return code
+}
+
+func writeError(writer http.ResponseWriter, code int, message string) int {
+	return writeJSON(writer, code, errorResponse{
+		Error: fmt.Sprint(http.StatusText(code), ": ", message),
+	})
+}
@@ -0,0 +1,243 @@
+package closesthttp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testTetrahedron0 = "[[0, 0, 0], [1, 0, 0], [0, 1, 0], [0, 0, 1]]"
+	testTetrahedron1 = "[[3, 0, 0], [4, 0, 0], [3, 1, 0], [3, 0, 1]]"
+	testTetrahedron2 = "[[0.5, 0, 0], [1.5, 0, 0], [0.5, 1, 0], [0.5, 0, 1]]"
+)
+
+func post(t *testing.T, server *httptest.Server, path string, body string, response any) int {
+	t.Helper()
+
+	result, err := server.Client().Post(server.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Body.Close()
+
+	err = json.NewDecoder(result.Body).Decode(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.StatusCode
+}
+
+func TestHandler_Measure(t *testing.T) {
+	server := httptest.NewServer(&Handler{})
+	defer server.Close()
+
+	response := measureResponse{}
+	code := post(t, server, "/measure", `{"convexHulls": [`+testTetrahedron0+`, `+testTetrahedron1+`], "direction": [1, 0, 0]}`, &response)
+	if code != http.StatusOK {
+		t.Fatal(code)
+	}
+	if math.Abs(response.Distance-2.0) > 1e-12 || response.Ons[0][0] != 1 || response.Ons[1][0] != 0 || response.Termination != "Converged" {
+		t.Error(response)
+	}
+
+	response = measureResponse{}
+	code = post(t, server, "/measure", `{"convexHulls": [`+testTetrahedron0+`, `+testTetrahedron2+`]}`, &response)
+	if code != http.StatusOK {
+		t.Fatal(code)
+	}
+	if !(response.Distance < 0.0) || response.Termination != "Expanded" {
+		t.Error(response)
+	}
+}
+
+func TestHandler_Intersects(t *testing.T) {
+	server := httptest.NewServer(&Handler{})
+	defer server.Close()
+
+	for _, testCase := range []struct {
+		other      string
+		intersects bool
+		distance   float64
+	}{
+		{testTetrahedron1, false, 2.0},
+		{testTetrahedron2, true, 0.0},
+	} {
+		response := intersectsResponse{}
+		code := post(t, server, "/intersects", `{"convexHulls": [`+testTetrahedron0+`, `+testCase.other+`]}`, &response)
+		if code != http.StatusOK {
+			t.Fatal(code)
+		}
+		if response.Intersects != testCase.intersects || math.Abs(response.Distance-testCase.distance) > 1e-12 {
+			t.Error(response)
+		}
+	}
+}
+
+func TestHandler_Batch(t *testing.T) {
+	server := httptest.NewServer(&Handler{})
+	defer server.Close()
+
+	response := batchResponse{}
+	code := post(
+		t,
+		server,
+		"/batch",
+		`{"convexHulls": {"a": `+testTetrahedron0+`, "b": `+testTetrahedron1+`, "c": `+testTetrahedron2+`}, "pairs": [["a", "b"], ["a", "c"]], "nonnegative": true}`,
+		&response,
+	)
+	if code != http.StatusOK {
+		t.Fatal(code)
+	}
+	if len(response.Results) != 2 || response.Results[0].Pair != [2]string{"a", "b"} ||
+		math.Abs(response.Results[0].Distance-2.0) > 1e-12 || response.Results[1].Termination != "Touched" {
+		t.Error(response)
+	}
+}
+
+func TestHandler_Validation(t *testing.T) {
+	server := httptest.NewServer(&Handler{MaxVertexCount: 4, MaxBodySize: 1 << 10})
+	defer server.Close()
+
+	for _, testCase := range []struct {
+		path string
+		body string
+		code int
+	}{
+		{"/measure", `{"convexHulls": [[], ` + testTetrahedron1 + `]}`, http.StatusBadRequest},
+		{"/measure", `{"convexHulls": [[[0, 0, 0], [1, 0, 0], [0, 1, 0], [0, 0, 1], [1, 1, 1]], ` + testTetrahedron1 + `]}`, http.StatusBadRequest},
+		{"/measure", `{"convexHulls": [` + testTetrahedron0 + `, ` + testTetrahedron1 + `], "tolerance": -1}`, http.StatusBadRequest},
+		{"/measure", `{"convexHulls": [` + testTetrahedron0 + `, ` + testTetrahedron1 + `], "unknown": 1}`, http.StatusBadRequest},
+		{"/measure", `{"convexHulls": [` + testTetrahedron0 + `, ` + testTetrahedron1 + `]} {}`, http.StatusBadRequest},
+		{"/measure", `{"convexHulls": [` + strings.Repeat(" ", 1<<10) + `]}`, http.StatusRequestEntityTooLarge},
+		{"/batch", `{"convexHulls": {"a": ` + testTetrahedron0 + `}, "pairs": [["a", "b"]]}`, http.StatusBadRequest},
+	} {
+		response := errorResponse{}
+		code := post(t, server, testCase.path, testCase.body, &response)
+		if code != testCase.code || response.Error == "" {
+			t.Error(testCase.body, ": ", code, " ", response)
+		}
+	}
+
+	result, err := server.Client().Get(server.URL + "/measure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result.Body.Close()
+	if result.StatusCode != http.StatusMethodNotAllowed {
+		t.Error(result.StatusCode)
+	}
+}
+
+func TestHandler_Timeout(t *testing.T) {
+	server := httptest.NewServer(&Handler{Timeout: time.Nanosecond})
+	defer server.Close()
+
+	body := strings.Builder{}
+	body.WriteString(`{"convexHulls": {"a": ` + testTetrahedron0 + `, "b": ` + testTetrahedron1 + `}, "pairs": [["a", "b"]`)
+	for i := 0; i < 1000; i += 1 {
+		body.WriteString(`, ["a", "b"]`)
+	}
+	body.WriteString(`]}`)
+
+	response := errorResponse{}
+	code := post(t, server, "/batch", body.String(), &response)
+	if code != http.StatusServiceUnavailable {
+		t.Error(code, " ", response)
+	}
+}
+
+func TestHandler_Timeout_Measure(t *testing.T) {
+	handler := &Handler{Timeout: 10 * time.Millisecond, MaxConcurrency: 1}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	body := `{"convexHulls": [` + testTetrahedron0 + `, ` + testTetrahedron1 + `]}`
+	result := measureResponse{}
+	code := post(t, server, "/measure", body, &result)
+	if code != http.StatusOK {
+		t.Fatal(code, " ", result)
+	}
+
+	// A measurement still running in the background keeps the only slot.
+	handler.slots <- struct{}{}
+	response := errorResponse{}
+	code = post(t, server, "/measure", body, &response)
+	if code != http.StatusServiceUnavailable {
+		t.Error(code, " ", response)
+	}
+
+	<-handler.slots
+	code = post(t, server, "/measure", body, &result)
+	if code != http.StatusOK {
+		t.Error(code, " ", result)
+	}
+}
+
+// panicRequest panics while measuring.
+type panicRequest struct{}
+
+func (request *panicRequest) validate(handler *Handler) error {
+	return nil
+}
+
+func (request *panicRequest) respond(ctx context.Context, metrics *metrics) (any, error) {
+	panic("test")
+}
+
+func TestHandler_Panic(t *testing.T) {
+	handler := &Handler{MaxConcurrency: 1}
+	handler.slots = make(chan struct{}, 1)
+	server := httptest.NewServer(handler.newEndpoint("panic", func() requestBody {
+		return &panicRequest{}
+	}))
+	defer server.Close()
+
+	response := errorResponse{}
+	code := post(t, server, "/", "{}", &response)
+	if code != http.StatusInternalServerError {
+		t.Error(code, " ", response)
+	}
+
+	// The slot is released after the panic.
+	select {
+	case handler.slots <- struct{}{}:
+	case <-time.After(time.Second):
+		t.Error("The slot is not released.")
+	}
+}
+
+func TestHandler_Metrics(t *testing.T) {
+	server := httptest.NewServer(&Handler{})
+	defer server.Close()
+
+	post(t, server, "/measure", `{"convexHulls": [`+testTetrahedron0+`, `+testTetrahedron1+`]}`, &measureResponse{})
+	post(t, server, "/measure", `{"convexHulls": []}`, &errorResponse{})
+
+	result, err := server.Client().Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Body.Close()
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		`closest_requests_total{endpoint="measure",code="200"} 1`,
+		`closest_requests_total{endpoint="measure",code="400"} 1`,
+		`closest_request_duration_seconds_count{endpoint="measure"} 2`,
+		`closest_measurements_total{termination="Converged"} 1`,
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Error("Missing ", line, " in\n", string(body))
+		}
+	}
+}
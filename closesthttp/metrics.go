@@ -0,0 +1,87 @@
+package closesthttp
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// metrics are the counters written in the Prometheus text format.
+type metrics struct {
+	mutex             sync.Mutex
+	requestCounts     map[[2]string]int // By the endpoints and the status codes
+	durationSums      map[string]float64
+	durationCounts    map[string]int
+	iterationCount    int
+	terminationCounts map[closest.Termination]int
+}
+
+func (metrics *metrics) observeRequest(endpoint string, code int, duration time.Duration) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if metrics.requestCounts == nil {
+		metrics.requestCounts = map[[2]string]int{}
+		metrics.durationSums = map[string]float64{}
+		metrics.durationCounts = map[string]int{}
+	}
+	metrics.requestCounts[[2]string{endpoint, strconv.Itoa(code)}] += 1
+	metrics.durationSums[endpoint] += duration.Seconds()
+	metrics.durationCounts[endpoint] += 1
+}
+
+func (metrics *metrics) observeMeasure(measure *closest.Measure) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	if metrics.terminationCounts == nil {
+		metrics.terminationCounts = map[closest.Termination]int{}
+	}
+	metrics.iterationCount += measure.IterationCount
+	metrics.terminationCounts[measure.Termination] += 1
+}
+
+func (metrics *metrics) write(writer io.Writer) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	fmt.Fprintln(writer, "# HELP closest_requests_total The number of the requests by the endpoints and the status codes.")
+	fmt.Fprintln(writer, "# TYPE closest_requests_total counter")
+	keys := [][2]string{}
+	for key := range metrics.requestCounts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i int, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		fmt.Fprintf(writer, "closest_requests_total{endpoint=%q,code=%q} %d\n", key[0], key[1], metrics.requestCounts[key])
+	}
+
+	fmt.Fprintln(writer, "# HELP closest_request_duration_seconds The time to serve the requests by the endpoints.")
+	fmt.Fprintln(writer, "# TYPE closest_request_duration_seconds summary")
+	endpoints := []string{}
+	for endpoint := range metrics.durationCounts {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(writer, "closest_request_duration_seconds_sum{endpoint=%q} %v\n", endpoint, metrics.durationSums[endpoint])
+		fmt.Fprintf(writer, "closest_request_duration_seconds_count{endpoint=%q} %d\n", endpoint, metrics.durationCounts[endpoint])
+	}
+
+	fmt.Fprintln(writer, "# HELP closest_iterations_total The number of the support points GJK calculated.")
+	fmt.Fprintln(writer, "# TYPE closest_iterations_total counter")
+	fmt.Fprintf(writer, "closest_iterations_total %d\n", metrics.iterationCount)
+
+	fmt.Fprintln(writer, "# HELP closest_measurements_total The number of the measurements by the terminations.")
+	fmt.Fprintln(writer, "# TYPE closest_measurements_total counter")
+	for termination := closest.Converged; termination <= closest.NoVertices; termination += 1 {
+		fmt.Fprintf(writer, "closest_measurements_total{termination=%q} %d\n", termination, metrics.terminationCounts[termination])
+	}
+}
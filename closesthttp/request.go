@@ -0,0 +1,236 @@
+package closesthttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// measureRequest is the body of a request to the measure endpoint.
+type measureRequest struct {
+	// ConvexHulls are the vertices of the two convex hulls.
+	ConvexHulls [2][][3]float64 `json:"convexHulls"`
+	// Tolerance is the same as [closest.Measure].
+	Tolerance float64 `json:"tolerance"`
+	// Direction is the initial direction from the first convex hull to the second one.
+	Direction *[3]float64 `json:"direction,omitempty"`
+	// Nonnegative measures the nonnegative distance instead of the depth.
+	Nonnegative bool `json:"nonnegative"`
+}
+
+// measureResponse is the body of a response from the measure endpoint.
+type measureResponse struct {
+	Distance       float64       `json:"distance"`
+	Direction      [3]float64    `json:"direction"`
+	Points         [2][3]float64 `json:"points"`
+	Ons            [2][]int      `json:"ons"`
+	IterationCount int           `json:"iterationCount"`
+	Termination    string        `json:"termination"`
+}
+
+func (request *measureRequest) validate(handler *Handler) error {
+	for i, convex := range request.ConvexHulls {
+		err := validateConvexHull(convex, handler.getMaxVertexCount())
+		if err != nil {
+			return fmt.Errorf("convexHulls[%d]: %w", i, err)
+		}
+	}
+	if request.Direction != nil && !isFinite(*request.Direction) {
+		return errors.New("direction: not finite")
+	}
+	return validateTolerance(request.Tolerance)
+}
+
+func (request *measureRequest) respond(ctx context.Context, metrics *metrics) (any, error) {
+	measure := request.measure(metrics)
+	return newMeasureResponse(measure), nil
+}
+
+func (request *measureRequest) measure(metrics *metrics) *closest.Measure {
+	measure := &closest.Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			toConvexHull(request.ConvexHulls[0]),
+			toConvexHull(request.ConvexHulls[1]),
+		},
+		Tolerance: request.Tolerance,
+	}
+	if request.Direction != nil {
+		measure.Direction = *request.Direction
+	}
+	measureDistance(measure, request.Nonnegative, metrics)
+
+	return measure
+}
+
+// intersectsRequest is the body of a request to the intersects endpoint.
+// Nonnegative is ignored.
+type intersectsRequest struct {
+	measureRequest
+}
+
+// intersectsResponse is the body of a response from the intersects endpoint.
+type intersectsResponse struct {
+	// Intersects reports whether the convex hulls are touching or intersecting within the tolerance.
+	Intersects bool `json:"intersects"`
+	// Distance is zero if Intersects, or the distance otherwise.
+	Distance float64 `json:"distance"`
+}
+
+func (request *intersectsRequest) respond(ctx context.Context, metrics *metrics) (any, error) {
+	request.Nonnegative = true
+	measure := request.measure(metrics)
+
+	response := intersectsResponse{
+		Intersects: measure.Termination == closest.Touched,
+	}
+	if !response.Intersects {
+		response.Distance = measure.Distance
+	}
+	return response, nil
+}
+
+// batchRequest is the body of a request to the batch endpoint.
+type batchRequest struct {
+	// ConvexHulls are the vertices of the convex hulls by their names.
+	ConvexHulls map[string][][3]float64 `json:"convexHulls"`
+	// Pairs are the pairs of the names of ConvexHulls to measure.
+	Pairs [][2]string `json:"pairs"`
+	// Tolerance is the same as [closest.Measure].
+	Tolerance float64 `json:"tolerance"`
+	// Nonnegative measures the nonnegative distance instead of the depth.
+	Nonnegative bool `json:"nonnegative"`
+}
+
+// batchResponse is the body of a response from the batch endpoint.
+type batchResponse struct {
+	// Results are in the order of Pairs.
+	Results []batchResult `json:"results"`
+}
+
+type batchResult struct {
+	Pair [2]string `json:"pair"`
+	measureResponse
+}
+
+func (request *batchRequest) validate(handler *Handler) error {
+	for name, convex := range request.ConvexHulls {
+		err := validateConvexHull(convex, handler.getMaxVertexCount())
+		if err != nil {
+			return fmt.Errorf("convexHulls[%q]: %w", name, err)
+		}
+	}
+	if len(request.Pairs) > handler.getMaxPairCount() {
+		return fmt.Errorf("pairs: more than %d", handler.getMaxPairCount())
+	}
+	for i, pair := range request.Pairs {
+		for _, name := range pair {
+			if _, ok := request.ConvexHulls[name]; !ok {
+				return fmt.Errorf("pairs[%d]: unknown convex hull %q", i, name)
+			}
+		}
+	}
+	return validateTolerance(request.Tolerance)
+}
+
+func (request *batchRequest) respond(ctx context.Context, metrics *metrics) (any, error) {
+	convexHulls := map[string][]*mgl64.Vec3{}
+	for name, convex := range request.ConvexHulls {
+		convexHulls[name] = toConvexHull(convex)
+	}
+
+	response := batchResponse{
+		Results: make([]batchResult, len(request.Pairs)),
+	}
+	for i, pair := range request.Pairs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		measure := &closest.Measure{
+			ConvexHulls: [2][]*mgl64.Vec3{
+				convexHulls[pair[0]],
+				convexHulls[pair[1]],
+			},
+			Tolerance: request.Tolerance,
+		}
+		measureDistance(measure, request.Nonnegative, metrics)
+		response.Results[i] = batchResult{
+			Pair:            pair,
+			measureResponse: newMeasureResponse(measure),
+		}
+	}
+
+	return response, nil
+}
+
+func measureDistance(measure *closest.Measure, isNonnegative bool, metrics *metrics) {
+	if isNonnegative {
+		measure.MeasureNonnegativeDistance()
+	} else {
+		measure.MeasureDistance()
+	}
+	metrics.observeMeasure(measure)
+}
+
+func newMeasureResponse(measure *closest.Measure) (response measureResponse) {
+	response.Distance = measure.Distance
+	response.Direction = measure.Direction
+	response.IterationCount = measure.IterationCount
+	response.Termination = measure.Termination.String()
+	for i := range measure.Points {
+		response.Points[i] = measure.Points[i]
+		response.Ons[i] = []int{}
+		for index := range measure.Ons[i] {
+			response.Ons[i] = append(response.Ons[i], index)
+		}
+		sort.Ints(response.Ons[i])
+	}
+
+	return
+}
+
+func validateConvexHull(convex [][3]float64, maxVertexCount int) error {
+	if len(convex) == 0 {
+		return errors.New("no vertices")
+	}
+	if len(convex) > maxVertexCount {
+		return fmt.Errorf("more than %d vertices", maxVertexCount)
+	}
+	for i, vertex := range convex {
+		if !isFinite(vertex) {
+			return fmt.Errorf("vertex %d: not finite", i)
+		}
+	}
+
+	return nil
+}
+
+func validateTolerance(tolerance float64) error {
+	if !(tolerance >= 0.0 && tolerance < 1.0) {
+		return errors.New("tolerance: out of [0, 1)")
+	}
+	return nil
+}
+
+func isFinite(vector [3]float64) bool {
+	for _, component := range vector {
+		if math.IsNaN(component) || math.IsInf(component, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func toConvexHull(vertices [][3]float64) []*mgl64.Vec3 {
+	convex := make([]*mgl64.Vec3, len(vertices))
+	for i, vertex := range vertices {
+		vector := mgl64.Vec3(vertex)
+		convex[i] = &vector
+	}
+	return convex
+}
@@ -0,0 +1,80 @@
+package closestio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// Extrusion is a polygon extruded vertically between two altitudes, such as a restricted airspace.
+type Extrusion struct {
+	// Name is the id or the name property of the feature, if any.
+	Name string
+	// Rings are the outer ring and the holes of the polygon, by the longitudes and the latitudes in degrees
+	// or by the projected coordinates. They may be closed or not, and in any orientation.
+	Rings [][]mgl64.Vec2
+	// MinAltitude is the floor in meters.
+	MinAltitude float64
+	// MaxAltitude is the ceiling in meters.
+	MaxAltitude float64
+}
+
+// Extrude decomposes the polygon into convex polygons, and returns the prisms of them, which can be
+// ConvexHulls of [closest.Measure] or Compound of [closest.CompoundMeasure].
+// If enu is nil, Rings are the projected coordinates, and the prisms have the altitudes as z.
+// Otherwise, Rings are geodetic, and the prisms are converted into enu.
+// The polygon is decomposed in the coordinates of Rings, whose longitudes are unwrapped
+// around the first vertex if they are geodetic.
+func (extrusion *Extrusion) Extrude(enu *closest.ENU) (prisms [][]*mgl64.Vec3, err error) {
+	if !(extrusion.MinAltitude <= extrusion.MaxAltitude) {
+		return nil, errors.New("closestio: the floor is above the ceiling")
+	}
+
+	rings := extrusion.Rings
+	if enu != nil {
+		rings = unwrapLongitudes(rings)
+	}
+	convexes, err := decomposeConvex(rings)
+	if err != nil {
+		return nil, fmt.Errorf("closestio: %w", err)
+	}
+
+	for _, convex := range convexes {
+		prism := make([]*mgl64.Vec3, 0, 2*len(convex))
+		for _, altitude := range []float64{extrusion.MinAltitude, extrusion.MaxAltitude} {
+			for _, point := range convex {
+				vertex := mgl64.Vec3{point[0], point[1], altitude}
+				if enu != nil {
+					vertex = enu.FromGeodetic(vertex)
+				}
+				prism = append(prism, &vertex)
+			}
+		}
+		prisms = append(prisms, prism)
+	}
+
+	return
+}
+
+// unwrapLongitudes shifts each longitude by multiples of 360 degrees to within 180 degrees of the first vertex,
+// so that a polygon across the antimeridian is not decomposed around the globe.
+func unwrapLongitudes(rings [][]mgl64.Vec2) (unwrapped [][]mgl64.Vec2) {
+	if len(rings) == 0 || len(rings[0]) == 0 {
+		return rings
+	}
+
+	origin := rings[0][0][0]
+	for _, ring := range rings {
+		unwrappedRing := make([]mgl64.Vec2, len(ring))
+		for i, point := range ring {
+			point[0] = origin + math.Remainder(point[0]-origin, 360.0)
+			unwrappedRing[i] = point
+		}
+		unwrapped = append(unwrapped, unwrappedRing)
+	}
+
+	return
+}
@@ -0,0 +1,142 @@
+package closestio
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+func TestExtrusion_Extrude(t *testing.T) {
+	// An L-shaped airspace of 2 km sides in a projected frame
+	extrusion := Extrusion{
+		Rings: [][]mgl64.Vec2{
+			{{0, 0}, {2000, 0}, {2000, 1000}, {1000, 1000}, {1000, 2000}, {0, 2000}},
+		},
+		MinAltitude: 0.0,
+		MaxAltitude: 150.0,
+	}
+	prisms, err := extrusion.Extrude(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prisms) != 2 {
+		t.Fatal(prisms)
+	}
+
+	// A drone in the notch of the L
+	drone := []*mgl64.Vec3{
+		{1500, 1500, 100},
+		{1501, 1500, 100},
+		{1500, 1501, 100},
+		{1500, 1500, 101},
+	}
+	measure := closest.CompoundMeasure{
+		Compound:   prisms,
+		ConvexHull: drone,
+	}
+	measure.MeasureDistance()
+	if math.Abs(measure.Distance-500.0) > 1e-9 {
+		t.Error(measure.Distance)
+	}
+
+	extrusion.MinAltitude = 200.0
+	_, err = extrusion.Extrude(nil)
+	if err == nil {
+		t.Error("The floor above the ceiling is accepted.")
+	}
+
+	prisms, err = (&Extrusion{}).Extrude(nil)
+	if err == nil {
+		t.Error("The polygon without rings is extruded into ", prisms)
+	}
+}
+
+func TestExtrusion_Extrude_Geodetic(t *testing.T) {
+	extrusions, err := ReadGeoJSON(strings.NewReader(testAirspaceGeoJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	enu := closest.ENU{
+		Origin: mgl64.Vec3{139.0, 35.0, 0.0},
+	}
+	prisms, err := extrusions[0].Extrude(&enu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prisms) != 2 {
+		t.Fatal(prisms)
+	}
+
+	// 100 m above the ceiling at the origin
+	drone := []*mgl64.Vec3{
+		{10.0, 10.0, 250.0},
+		{11.0, 10.0, 250.0},
+		{10.0, 11.0, 250.0},
+		{10.0, 10.0, 251.0},
+	}
+	measure := closest.CompoundMeasure{
+		Compound:   prisms,
+		ConvexHull: drone,
+	}
+	measure.MeasureDistance()
+	if math.Abs(measure.Distance-100.0) > 0.01 {
+		t.Error(measure.Distance)
+	}
+}
+
+func TestExtrusion_Extrude_Antimeridian(t *testing.T) {
+	// A U opening to the north across the antimeridian
+	extrusion := Extrusion{
+		Rings: [][]mgl64.Vec2{{
+			{179.9, 0.0},
+			{-179.9, 0.0},
+			{-179.9, 0.2},
+			{-179.95, 0.2},
+			{-179.95, 0.05},
+			{179.95, 0.05},
+			{179.95, 0.2},
+			{179.9, 0.2},
+		}},
+		MinAltitude: 0.0,
+		MaxAltitude: 100.0,
+	}
+	enu := closest.ENU{
+		Origin: mgl64.Vec3{180.0, 0.1, 0.0},
+	}
+	prisms, err := extrusion.Extrude(&enu)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		center   mgl64.Vec3
+		isInside bool
+	}{
+		// In the notch, about 5.6 km from the arms
+		{mgl64.Vec3{180.0, 0.15, 50.0}, false},
+		// In the bottom
+		{mgl64.Vec3{-180.0, 0.025, 50.0}, true},
+	} {
+		center := enu.FromGeodetic(testCase.center)
+		drone := []*mgl64.Vec3{
+			{center[0], center[1], center[2]},
+			{center[0] + 1.0, center[1], center[2]},
+			{center[0], center[1] + 1.0, center[2]},
+			{center[0], center[1], center[2] + 1.0},
+		}
+		measure := closest.CompoundMeasure{
+			Compound:   prisms,
+			ConvexHull: drone,
+		}
+		measure.MeasureDistance()
+		if testCase.isInside != (measure.Distance < 0.0) {
+			t.Error(testCase.center, ": ", measure.Distance)
+		}
+		if !testCase.isInside && math.Abs(measure.Distance-5565.0) > 10.0 {
+			t.Error(testCase.center, ": ", measure.Distance)
+		}
+	}
+}
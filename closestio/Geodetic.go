@@ -0,0 +1,97 @@
+package closestio
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// Geodetic is a measurement of geodetic convex hulls to review in Google Earth by KML or in Cesium by CZML.
+// The coordinates are the longitudes and the latitudes in degrees, and the heights in meters.
+type Geodetic struct {
+	// Time is the time of the measurement. If this is zero, the measurement is not time-tagged.
+	Time time.Time
+	// ConvexHulls are the geodetic convex hulls.
+	ConvexHulls [2][]*mgl64.Vec3
+	// Points are the geodetic closest points.
+	Points [2]mgl64.Vec3
+	// Distance is in meters. If this is negative, this is the depth.
+	Distance float64
+}
+
+// MeasureGeodetic measures the geodetic convex hulls at the time in the ENU frame of [closest.NewENU].
+func MeasureGeodetic(at time.Time, convexHulls [2][]*mgl64.Vec3) (geodetic Geodetic) {
+	geodetic.Time = at
+	geodetic.ConvexHulls = convexHulls
+
+	enu := closest.NewENU(convexHulls[:]...)
+	measure := closest.Measure{}
+	for i, convex := range convexHulls {
+		measure.ConvexHulls[i] = enu.FromGeodetics(convex)
+	}
+	measure.MeasureDistance()
+
+	geodetic.Distance = measure.Distance
+	for i, point := range measure.Points {
+		geodetic.Points[i] = enu.ToGeodetic(point)
+	}
+
+	return
+}
+
+// getLabel returns the label of the segment of Points.
+func (geodetic *Geodetic) getLabel() string {
+	if geodetic.Distance < 0.0 {
+		return fmt.Sprintf("Depth: %.2f m", -geodetic.Distance)
+	}
+	return fmt.Sprintf("Distance: %.2f m", geodetic.Distance)
+}
+
+// getMidpoint returns the geodetic midpoint of the segment of Points, which is straight in the ENU frame.
+func (geodetic *Geodetic) getMidpoint() mgl64.Vec3 {
+	ecefs := [2]mgl64.Vec3{}
+	for i, point := range geodetic.Points {
+		ecefs[i] = closest.GeodeticToECEF(point)
+	}
+	return closest.ECEFToGeodetic(ecefs[0].Add(ecefs[1]).Mul(0.5))
+}
+
+// getInterval returns the interval of the i-th of the measurements in order of Time until the next one.
+// The last one lasts as long as the previous one. It returns false if the measurement is not time-tagged.
+func getInterval(geodetics []Geodetic, i int) (begin time.Time, end time.Time, ok bool) {
+	begin = geodetics[i].Time
+	if begin.IsZero() {
+		return
+	}
+
+	switch {
+	case i+1 < len(geodetics):
+		end = geodetics[i+1].Time
+	case i > 0:
+		end = begin.Add(begin.Sub(geodetics[i-1].Time))
+	default:
+		end = begin
+	}
+	ok = true
+	return
+}
+
+// newGeodeticHull returns the geodetic vertices and the faces of the convex hull of convex.
+// The convex hull is built in the ENU frame at its center, so it is not distorted by the degrees.
+// If the convex hull is degenerate, there are no faces.
+func newGeodeticHull(convex []*mgl64.Vec3) (vertices []mgl64.Vec3, faces [][3]int) {
+	if len(convex) == 0 {
+		return
+	}
+
+	enu := closest.NewENU(convex)
+	hull := closest.NewHull(enu.FromGeodetics(convex))
+	for _, vertex := range hull.Vertices {
+		vertices = append(vertices, enu.ToGeodetic(*vertex))
+	}
+	faces = hull.Faces
+
+	return
+}
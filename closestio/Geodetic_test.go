@@ -0,0 +1,73 @@
+package closestio
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// newTestGeodetics returns the measurements of two tetrahedra approaching each other by 10 m every second.
+func newTestGeodetics(count int) (geodetics []Geodetic) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < count; i += 1 {
+		height := 110.0 - 10.0*float64(i)
+		convexHulls := [2][]*mgl64.Vec3{
+			{
+				{139.0, 35.0, 0.0},
+				{139.001, 35.0, 0.0},
+				{139.0, 35.001, 0.0},
+				{139.0, 35.0, 10.0},
+			},
+			{
+				{139.0, 35.0, height},
+				{139.001, 35.0, height},
+				{139.0, 35.001, height},
+				{139.0, 35.0, height + 10.0},
+			},
+		}
+		geodetics = append(geodetics, MeasureGeodetic(start.Add(time.Duration(i)*time.Second), convexHulls))
+	}
+
+	return
+}
+
+func TestMeasureGeodetic(t *testing.T) {
+	geodetics := newTestGeodetics(2)
+	if math.Abs(geodetics[0].Distance-100.0) > 1e-3 || math.Abs(geodetics[1].Distance-90.0) > 1e-3 {
+		t.Error(geodetics[0].Distance, geodetics[1].Distance)
+	}
+	for i, point := range geodetics[0].Points {
+		if math.Abs(point[2]-[2]float64{10.0, 110.0}[i]) > 1e-3 {
+			t.Error("Points[", i, "]: ", point)
+		}
+	}
+	if geodetics[0].getLabel() != "Distance: 100.00 m" {
+		t.Error(geodetics[0].getLabel())
+	}
+
+	begin, end, ok := getInterval(geodetics, 1)
+	if !ok || !begin.Equal(geodetics[1].Time) || end.Sub(begin) != time.Second {
+		t.Error(begin, end, ok)
+	}
+	_, _, ok = getInterval([]Geodetic{{}}, 0)
+	if ok {
+		t.Error("The measurement without Time has an interval.")
+	}
+}
+
+func TestMeasureGeodetic_Antimeridian(t *testing.T) {
+	geodetic := MeasureGeodetic(time.Time{}, [2][]*mgl64.Vec3{
+		{{179.999, 0.0, 0.0}, {179.999, 0.001, 0.0}, {179.999, 0.0, 10.0}},
+		{{-179.999, 0.0, 0.0}, {-179.999, 0.001, 0.0}, {-179.999, 0.0, 10.0}},
+	})
+	if math.Abs(geodetic.Distance-222.6) > 0.1 {
+		t.Error(geodetic.Distance)
+	}
+
+	midpoint := geodetic.getMidpoint()
+	if math.Abs(math.Abs(midpoint[0])-180.0) > 1e-9 || math.Abs(midpoint[2]) > 1e-2 {
+		t.Error(midpoint)
+	}
+}
@@ -0,0 +1,126 @@
+// Package closestio reads and writes the files of the convex hulls of closest.
+package closestio
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// Object is a named object or group of a mesh file.
+// Vertices are only the ones of the object, so they can be used as ConvexHulls of [closest.Measure].
+type Object struct {
+	Name string
+	closest.Mesh
+}
+
+// Axes is the convention of the axes of a file. The results are always right-handed with the z axis up.
+type Axes int
+
+const (
+	// ZUp is right-handed with the z axis up. The coordinates are not converted.
+	ZUp Axes = iota
+	// YUp is right-handed with the y axis up, which is common in OBJ and glTF.
+	// The coordinates (x, y, z) are converted into (x, -z, y).
+	YUp
+)
+
+// ReadOptions are the options of reading mesh files.
+type ReadOptions struct {
+	// Scale multiplies the coordinates, for example, 0.001 for millimeters into meters.
+	// If this is zero, the coordinates are not scaled.
+	Scale float64
+	// Axes is the convention of the axes of the file.
+	Axes Axes
+}
+
+func (options *ReadOptions) convert(vertex mgl64.Vec3) mgl64.Vec3 {
+	if options.Scale != 0.0 {
+		vertex = vertex.Mul(options.Scale)
+	}
+	if options.Axes == YUp {
+		vertex = mgl64.Vec3{vertex[0], -vertex[2], vertex[1]}
+	}
+
+	return vertex
+}
+
+// ReadMeshFile reads the objects of an OBJ, STL or PLY file by its extension.
+func ReadMeshFile(path string, options ReadOptions) ([]Object, error) {
+	var read func(io.Reader, ReadOptions) ([]Object, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".obj":
+		read = ReadOBJ
+	case ".stl":
+		read = ReadSTL
+	case ".ply":
+		read = ReadPLY
+	default:
+		return nil, fmt.Errorf("closestio: unknown extension: %s", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	objects, err := read(file, options)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return objects, nil
+}
+
+// objectBuilder builds an object by the triangles of the vertices shared in a file.
+type objectBuilder struct {
+	object  Object
+	indices map[int]int // The indices of Vertices by the ones of the file
+}
+
+func newObjectBuilder(name string) *objectBuilder {
+	return &objectBuilder{
+		object: Object{
+			Name: name,
+		},
+		indices: map[int]int{},
+	}
+}
+
+// addTriangle adds the triangle of the indices of vertices.
+func (builder *objectBuilder) addTriangle(vertices []mgl64.Vec3, triangle [3]int) {
+	for i, index := range triangle {
+		newIndex, ok := builder.indices[index]
+		if !ok {
+			newIndex = len(builder.object.Vertices)
+			vertex := vertices[index]
+			builder.object.Vertices = append(builder.object.Vertices, &vertex)
+			builder.indices[index] = newIndex
+		}
+		triangle[i] = newIndex
+	}
+	builder.object.Triangles = append(builder.object.Triangles, triangle)
+}
+
+// addPolygon adds the polygon of the indices of vertices as a triangle fan.
+func (builder *objectBuilder) addPolygon(vertices []mgl64.Vec3, polygon []int) {
+	for i := 2; i < len(polygon); i += 1 {
+		builder.addTriangle(vertices, [3]int{polygon[0], polygon[i-1], polygon[i]})
+	}
+}
+
+// newPointCloud returns the object of the vertices without triangles.
+func newPointCloud(name string, vertices []mgl64.Vec3) Object {
+	object := Object{
+		Name: name,
+	}
+	for i := range vertices {
+		object.Vertices = append(object.Vertices, &vertices[i])
+	}
+	return object
+}
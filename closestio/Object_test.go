@@ -0,0 +1,35 @@
+package closestio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadMeshFile(t *testing.T) {
+	directory := t.TempDir()
+	for name, data := range map[string][]byte{
+		"cubes.obj":       []byte(testCubeOBJ),
+		"tetrahedron.STL": newTestBinarySTL(""),
+		"tetrahedron.ply": newTestPLY("ascii", nil),
+	} {
+		path := filepath.Join(directory, name)
+		err := os.WriteFile(path, data, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		objects, err := ReadMeshFile(path, ReadOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(objects) == 0 || len(objects[0].Triangles) == 0 {
+			t.Error(name, ": ", objects)
+		}
+	}
+
+	_, err := ReadMeshFile(filepath.Join(directory, "unknown.dae"), ReadOptions{})
+	if err == nil {
+		t.Error("An unknown extension is accepted.")
+	}
+}
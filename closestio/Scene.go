@@ -0,0 +1,485 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// Scene is a measurement to inspect in a 3D viewer.
+// Each part is drawn in its own material: the convex hulls, the closest points as small octahedra,
+// the segment of Direction from Points[0], and Polytope.
+type Scene struct {
+	// ConvexHulls are drawn as their convex hulls.
+	ConvexHulls [2][]*mgl64.Vec3
+	// Points are the closest points.
+	Points [2]mgl64.Vec3
+	// Direction is from Points[0].
+	Direction mgl64.Vec3
+	// Polytope is the final polytope of EPA in the Minkowski difference. It is not drawn if nil.
+	Polytope *closest.Mesh
+	// History is the simplices of GJK in the Minkowski difference. It is drawn only in SVG.
+	History [][][2]int
+	// MarkerSize is the radius of the octahedra of Points. If this is zero, 1% of the size of the scene is used.
+	MarkerSize float64
+}
+
+// NewScene returns the scene of the last measurement of measure.
+// Set KeepsPolytope and KeepsHistory of measure to draw the polytope of EPA and the simplices of GJK.
+func NewScene(measure *closest.Measure) *Scene {
+	return &Scene{
+		ConvexHulls: measure.ConvexHulls,
+		Points:      measure.Points,
+		Direction:   measure.Direction,
+		Polytope:    measure.Polytope,
+		History:     measure.History,
+	}
+}
+
+// sceneMaterial is a material of the parts of a scene.
+type sceneMaterial struct {
+	name  string
+	color [4]float32 // RGBA
+}
+
+var sceneMaterials = [...]sceneMaterial{
+	{"hull0", [4]float32{0.2, 0.4, 1.0, 0.5}},
+	{"hull1", [4]float32{1.0, 0.5, 0.1, 0.5}},
+	{"point", [4]float32{1.0, 0.0, 0.0, 1.0}},
+	{"direction", [4]float32{1.0, 1.0, 0.0, 1.0}},
+	{"polytope", [4]float32{0.2, 0.8, 0.2, 0.5}},
+}
+
+const (
+	hull0Material = iota
+	hull1Material
+	pointMaterial
+	directionMaterial
+	polytopeMaterial
+)
+
+// scenePart is a part of a scene drawn in a material.
+type scenePart struct {
+	name      string
+	material  int
+	vertices  []mgl64.Vec3
+	triangles [][3]int
+	lines     [][2]int
+}
+
+// getParts returns the non-empty parts of scene.
+func (scene *Scene) getParts() (parts []scenePart) {
+	bounds := []*mgl64.Vec3{}
+	for _, convex := range scene.ConvexHulls {
+		bounds = append(bounds, convex...)
+	}
+	bounds = append(bounds, &scene.Points[0], &scene.Points[1])
+	markerSize := scene.MarkerSize
+	if markerSize == 0.0 {
+		mesh := closest.Mesh{Vertices: bounds}
+		min, max := mesh.Bounds()
+		markerSize = 0.01 * max.Sub(min).Len()
+		if markerSize == 0.0 {
+			markerSize = 0.01
+		}
+	}
+
+	for i, convex := range scene.ConvexHulls {
+		part := newConvexPart(convex, markerSize)
+		part.name = fmt.Sprint("hull", i)
+		part.material = hull0Material + i
+		parts = append(parts, part)
+	}
+	for i, point := range scene.Points {
+		part := newOctahedronPart(point, markerSize)
+		part.name = fmt.Sprint("point", i)
+		part.material = pointMaterial
+		parts = append(parts, part)
+	}
+	parts = append(parts, scenePart{
+		name:     "direction",
+		material: directionMaterial,
+		vertices: []mgl64.Vec3{scene.Points[0], scene.Points[0].Add(scene.Direction)},
+		lines:    [][2]int{{0, 1}},
+	})
+	if scene.Polytope != nil {
+		part := scenePart{
+			name:      "polytope",
+			material:  polytopeMaterial,
+			triangles: scene.Polytope.Triangles,
+		}
+		for _, vertex := range scene.Polytope.Vertices {
+			part.vertices = append(part.vertices, *vertex)
+		}
+		parts = append(parts, part)
+	}
+
+	nonEmptyParts := parts[:0]
+	for _, part := range parts {
+		if len(part.vertices) != 0 {
+			nonEmptyParts = append(nonEmptyParts, part)
+		}
+	}
+	return nonEmptyParts
+}
+
+// newConvexPart returns the part of the convex hull of convex.
+// The degenerate convex hull is a segment or an octahedron.
+func newConvexPart(convex []*mgl64.Vec3, markerSize float64) (part scenePart) {
+	if len(convex) == 0 {
+		return
+	}
+
+	hull := closest.NewHull(convex)
+	switch {
+	case len(hull.Faces) != 0:
+		part.triangles = hull.Faces
+	case len(hull.Vertices) == 2:
+		part.lines = [][2]int{{0, 1}}
+	default:
+		return newOctahedronPart(*convex[0], markerSize)
+	}
+	for _, vertex := range hull.Vertices {
+		part.vertices = append(part.vertices, *vertex)
+	}
+
+	return
+}
+
+func newOctahedronPart(center mgl64.Vec3, radius float64) (part scenePart) {
+	for i := 0; i < 3; i += 1 {
+		for _, sign := range []float64{1.0, -1.0} {
+			vertex := center
+			vertex[i] += sign * radius
+			part.vertices = append(part.vertices, vertex)
+		}
+	}
+	// The vertices are +x, -x, +y, -y, +z and -z.
+	for _, x := range []int{0, 1} {
+		for _, y := range []int{2, 3} {
+			for _, z := range []int{4, 5} {
+				triangle := [3]int{x, y, z}
+				if (x+y+z)%2 == 1 {
+					// The odd number of the negative axes
+					triangle[1], triangle[2] = z, y
+				}
+				part.triangles = append(part.triangles, triangle)
+			}
+		}
+	}
+
+	return
+}
+
+// WriteFile writes scene into the file by its extension: .obj with .mtl, .gltf, .glb or .svg of all the planes.
+func (scene *Scene) WriteFile(path string) (err error) {
+	extension := strings.ToLower(filepath.Ext(path))
+	switch extension {
+	case ".obj", ".gltf", ".glb", ".svg":
+	default:
+		return fmt.Errorf("closestio: unknown extension: %s", path)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	switch extension {
+	case ".obj":
+		mtlPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".mtl"
+		mtl, err := os.Create(mtlPath)
+		if err != nil {
+			return err
+		}
+		defer mtl.Close()
+		return scene.WriteOBJ(file, mtl, filepath.Base(mtlPath))
+	case ".gltf":
+		return scene.WriteGLTF(file)
+	case ".svg":
+		return scene.WriteSVG(file)
+	default:
+		return scene.WriteGLB(file)
+	}
+}
+
+// WriteOBJ writes scene into obj, and its materials into mtl referred by mtlName from obj.
+// The segment is a line element.
+func (scene *Scene) WriteOBJ(obj io.Writer, mtl io.Writer, mtlName string) error {
+	for _, material := range sceneMaterials {
+		_, err := fmt.Fprintf(
+			mtl,
+			"newmtl %s\nKd %v %v %v\nd %v\n\n",
+			material.name,
+			material.color[0],
+			material.color[1],
+			material.color[2],
+			material.color[3],
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "mtllib %s\n", mtlName)
+	offset := 1
+	for _, part := range scene.getParts() {
+		fmt.Fprintf(&buffer, "o %s\nusemtl %s\n", part.name, sceneMaterials[part.material].name)
+		for _, vertex := range part.vertices {
+			fmt.Fprintf(&buffer, "v %v %v %v\n", vertex[0], vertex[1], vertex[2])
+		}
+		for _, triangle := range part.triangles {
+			fmt.Fprintf(&buffer, "f %d %d %d\n", triangle[0]+offset, triangle[1]+offset, triangle[2]+offset)
+		}
+		for _, line := range part.lines {
+			fmt.Fprintf(&buffer, "l %d %d\n", line[0]+offset, line[1]+offset)
+		}
+		offset += len(part.vertices)
+	}
+
+	_, err := buffer.WriteTo(obj)
+	return err
+}
+
+// WriteGLTF writes scene into a glTF file with the embedded buffer.
+// The z axis up is converted into the y axis up of glTF.
+func (scene *Scene) WriteGLTF(writer io.Writer) error {
+	document, data := scene.newGLTF()
+	document.Buffers[0].URI = "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(data)
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+// WriteGLB writes scene into a binary glTF file.
+// The z axis up is converted into the y axis up of glTF.
+func (scene *Scene) WriteGLB(writer io.Writer) error {
+	document, data := scene.newGLTF()
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	for len(jsonData)%4 != 0 {
+		jsonData = append(jsonData, ' ')
+	}
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+
+	glb := []byte("glTF")
+	glb = binary.LittleEndian.AppendUint32(glb, 2)
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(12+8+len(jsonData)+8+len(data)))
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(len(jsonData)))
+	glb = append(glb, "JSON"...)
+	glb = append(glb, jsonData...)
+	glb = binary.LittleEndian.AppendUint32(glb, uint32(len(data)))
+	glb = append(glb, "BIN\x00"...)
+	glb = append(glb, data...)
+
+	_, err = writer.Write(glb)
+	return err
+}
+
+// The subset of glTF 2.0
+type (
+	gltf struct {
+		Asset       gltfAsset        `json:"asset"`
+		Scene       int              `json:"scene"`
+		Scenes      []gltfScene      `json:"scenes"`
+		Nodes       []gltfNode       `json:"nodes"`
+		Meshes      []gltfMesh       `json:"meshes"`
+		Materials   []gltfMaterial   `json:"materials"`
+		Accessors   []gltfAccessor   `json:"accessors"`
+		BufferViews []gltfBufferView `json:"bufferViews"`
+		Buffers     []gltfBuffer     `json:"buffers"`
+	}
+	gltfAsset struct {
+		Version   string `json:"version"`
+		Generator string `json:"generator"`
+	}
+	gltfScene struct {
+		Nodes []int `json:"nodes"`
+	}
+	gltfNode struct {
+		Name        string     `json:"name"`
+		Mesh        int        `json:"mesh"`
+		Translation [3]float64 `json:"translation"`
+	}
+	gltfMesh struct {
+		Name       string          `json:"name"`
+		Primitives []gltfPrimitive `json:"primitives"`
+	}
+	gltfPrimitive struct {
+		Attributes map[string]int `json:"attributes"`
+		Indices    int            `json:"indices"`
+		Material   int            `json:"material"`
+		Mode       int            `json:"mode"`
+	}
+	gltfMaterial struct {
+		Name                 string                   `json:"name"`
+		PBRMetallicRoughness gltfPBRMetallicRoughness `json:"pbrMetallicRoughness"`
+		AlphaMode            string                   `json:"alphaMode"`
+		DoubleSided          bool                     `json:"doubleSided"`
+	}
+	gltfPBRMetallicRoughness struct {
+		BaseColorFactor [4]float32 `json:"baseColorFactor"`
+		MetallicFactor  float32    `json:"metallicFactor"`
+	}
+	gltfAccessor struct {
+		BufferView    int       `json:"bufferView"`
+		ComponentType int       `json:"componentType"`
+		Count         int       `json:"count"`
+		Type          string    `json:"type"`
+		Min           []float32 `json:"min,omitempty"`
+		Max           []float32 `json:"max,omitempty"`
+	}
+	gltfBufferView struct {
+		Buffer     int `json:"buffer"`
+		ByteOffset int `json:"byteOffset"`
+		ByteLength int `json:"byteLength"`
+		Target     int `json:"target"`
+	}
+	gltfBuffer struct {
+		URI        string `json:"uri,omitempty"`
+		ByteLength int    `json:"byteLength"`
+	}
+)
+
+// The constants of glTF 2.0
+const (
+	gltfFloat              = 5126
+	gltfUnsignedInt        = 5125
+	gltfArrayBuffer        = 34962
+	gltfElementArrayBuffer = 34963
+	gltfLines              = 1
+	gltfTriangles          = 4
+)
+
+// toGLTFAxes converts the z axis up into the y axis up.
+func toGLTFAxes(vertex mgl64.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{vertex[0], vertex[2], -vertex[1]}
+}
+
+// newGLTF returns the document and the buffer of scene.
+// The vertices of each part are relative to its node in float32.
+func (scene *Scene) newGLTF() (document gltf, data []byte) {
+	document.Asset = gltfAsset{Version: "2.0", Generator: "closest_go"}
+	document.Scenes = []gltfScene{{Nodes: []int{}}}
+	for _, material := range sceneMaterials {
+		alphaMode := "OPAQUE"
+		if material.color[3] < 1.0 {
+			alphaMode = "BLEND"
+		}
+		document.Materials = append(document.Materials, gltfMaterial{
+			Name: material.name,
+			PBRMetallicRoughness: gltfPBRMetallicRoughness{
+				BaseColorFactor: material.color,
+			},
+			AlphaMode:   alphaMode,
+			DoubleSided: true,
+		})
+	}
+
+	addBufferView := func(viewData []byte, target int) int {
+		document.BufferViews = append(document.BufferViews, gltfBufferView{
+			ByteOffset: len(data),
+			ByteLength: len(viewData),
+			Target:     target,
+		})
+		data = append(data, viewData...)
+		return len(document.BufferViews) - 1
+	}
+
+	for _, part := range scene.getParts() {
+		vertices := make([]*mgl64.Vec3, len(part.vertices))
+		for i := range part.vertices {
+			vertex := toGLTFAxes(part.vertices[i])
+			vertices[i] = &vertex
+		}
+		mesh := closest.Mesh{Vertices: vertices}
+		min, max := mesh.Bounds()
+		center := min.Add(max).Mul(0.5)
+
+		positions := []byte{}
+		minPosition := []float32{float32(math.Inf(1)), float32(math.Inf(1)), float32(math.Inf(1))}
+		maxPosition := []float32{float32(math.Inf(-1)), float32(math.Inf(-1)), float32(math.Inf(-1))}
+		for _, vertex := range vertices {
+			for k := 0; k < 3; k += 1 {
+				position := float32(vertex[k] - center[k])
+				positions = binary.LittleEndian.AppendUint32(positions, math.Float32bits(position))
+				minPosition[k] = float32(math.Min(float64(minPosition[k]), float64(position)))
+				maxPosition[k] = float32(math.Max(float64(maxPosition[k]), float64(position)))
+			}
+		}
+		document.Accessors = append(document.Accessors, gltfAccessor{
+			BufferView:    addBufferView(positions, gltfArrayBuffer),
+			ComponentType: gltfFloat,
+			Count:         len(vertices),
+			Type:          "VEC3",
+			Min:           minPosition,
+			Max:           maxPosition,
+		})
+
+		indices := []byte{}
+		count := 0
+		mode := gltfTriangles
+		for _, triangle := range part.triangles {
+			for _, index := range triangle {
+				indices = binary.LittleEndian.AppendUint32(indices, uint32(index))
+				count += 1
+			}
+		}
+		if len(part.lines) != 0 {
+			mode = gltfLines
+			for _, line := range part.lines {
+				for _, index := range line {
+					indices = binary.LittleEndian.AppendUint32(indices, uint32(index))
+					count += 1
+				}
+			}
+		}
+		document.Accessors = append(document.Accessors, gltfAccessor{
+			BufferView:    addBufferView(indices, gltfElementArrayBuffer),
+			ComponentType: gltfUnsignedInt,
+			Count:         count,
+			Type:          "SCALAR",
+		})
+
+		document.Meshes = append(document.Meshes, gltfMesh{
+			Name: part.name,
+			Primitives: []gltfPrimitive{{
+				Attributes: map[string]int{"POSITION": len(document.Accessors) - 2},
+				Indices:    len(document.Accessors) - 1,
+				Material:   part.material,
+				Mode:       mode,
+			}},
+		})
+		document.Nodes = append(document.Nodes, gltfNode{
+			Name:        part.name,
+			Mesh:        len(document.Meshes) - 1,
+			Translation: center,
+		})
+		document.Scenes[0].Nodes = append(document.Scenes[0].Nodes, len(document.Nodes)-1)
+	}
+	document.Buffers = []gltfBuffer{{ByteLength: len(data)}}
+
+	return
+}
@@ -0,0 +1,168 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+func newTestScene() *Scene {
+	newCube := func(min mgl64.Vec3) (cube []*mgl64.Vec3) {
+		for i := 0; i < 8; i += 1 {
+			vertex := min
+			for k := 0; k < 3; k += 1 {
+				if i&(1<<k) != 0 {
+					vertex[k] += 1.0
+				}
+			}
+			cube = append(cube, &vertex)
+		}
+		return
+	}
+
+	measure := closest.Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			newCube(mgl64.Vec3{0.0, 0.0, 0.0}),
+			newCube(mgl64.Vec3{0.5, 0.25, 0.125}),
+		},
+		KeepsPolytope: true,
+		KeepsHistory:  true,
+	}
+	measure.MeasureDistance()
+	return NewScene(&measure)
+}
+
+func TestScene_WriteOBJ(t *testing.T) {
+	scene := newTestScene()
+	if scene.Polytope == nil {
+		t.Fatal("Polytope is nil.")
+	}
+
+	obj := bytes.Buffer{}
+	mtl := bytes.Buffer{}
+	err := scene.WriteOBJ(&obj, &mtl, "scene.mtl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, material := range sceneMaterials {
+		if !strings.Contains(mtl.String(), "newmtl "+material.name+"\n") {
+			t.Error("No material: ", material.name)
+		}
+	}
+	if !strings.Contains(obj.String(), "\nl ") {
+		t.Error("No segment of Direction.")
+	}
+
+	objects, err := ReadOBJ(&obj, ReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The segment without faces is not read.
+	names := []string{}
+	for _, object := range objects {
+		names = append(names, object.Name)
+	}
+	if strings.Join(names, " ") != "hull0 hull1 point0 point1 polytope" {
+		t.Error(names)
+	}
+	for _, object := range objects {
+		if object.Name == "hull0" && len(object.Triangles) != 12 {
+			t.Error("hull0: ", object.Triangles)
+		}
+		if object.Name == "point0" && len(object.Triangles) != 8 {
+			t.Error("point0: ", object.Triangles)
+		}
+	}
+}
+
+func TestScene_WriteGLTF(t *testing.T) {
+	scene := newTestScene()
+
+	buffer := bytes.Buffer{}
+	err := scene.WriteGLTF(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := gltf{}
+	err = json.Unmarshal(buffer.Bytes(), &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(document.Nodes) != 6 || len(document.Materials) != len(sceneMaterials) {
+		t.Fatal(document.Nodes, document.Materials)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(document.Buffers[0].URI, "data:application/octet-stream;base64,"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != document.Buffers[0].ByteLength {
+		t.Error(len(data), document.Buffers[0].ByteLength)
+	}
+
+	for i, mesh := range document.Meshes {
+		primitive := mesh.Primitives[0]
+		positions := document.Accessors[primitive.Attributes["POSITION"]]
+		indices := document.Accessors[primitive.Indices]
+		view := document.BufferViews[indices.BufferView]
+		for j := 0; j < indices.Count; j += 1 {
+			index := binary.LittleEndian.Uint32(data[view.ByteOffset+4*j:])
+			if int(index) >= positions.Count {
+				t.Error(mesh.Name, ": ", index, " is out of ", positions.Count)
+			}
+		}
+
+		isLines := mesh.Name == "direction"
+		if isLines != (primitive.Mode == gltfLines) {
+			t.Error(mesh.Name, ": ", primitive.Mode)
+		}
+		if document.Nodes[i].Mesh != i {
+			t.Error(document.Nodes[i])
+		}
+	}
+
+	// Points[0] is the center of the node of point0 converted into the y axis up.
+	translation := mgl64.Vec3(document.Nodes[2].Translation)
+	if !translation.ApproxEqual(toGLTFAxes(scene.Points[0])) {
+		t.Error(translation, scene.Points[0])
+	}
+}
+
+func TestScene_WriteFile(t *testing.T) {
+	scene := newTestScene()
+	directory := t.TempDir()
+
+	err := scene.WriteFile(filepath.Join(directory, "scene.glb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	glb, err := os.ReadFile(filepath.Join(directory, "scene.glb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(glb[:4]) != "glTF" || int(binary.LittleEndian.Uint32(glb[8:])) != len(glb) || len(glb)%4 != 0 {
+		t.Error("Invalid header: ", glb[:12])
+	}
+
+	err = scene.WriteFile(filepath.Join(directory, "scene.obj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(directory, "scene.mtl"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = scene.WriteFile(filepath.Join(directory, "scene.dae"))
+	if err == nil {
+		t.Error("An unknown extension is accepted.")
+	}
+}
@@ -0,0 +1,174 @@
+package closestio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// The subset of CZML
+type (
+	czmlPacket struct {
+		ID           string        `json:"id"`
+		Name         string        `json:"name,omitempty"`
+		Version      string        `json:"version,omitempty"`
+		Clock        *czmlClock    `json:"clock,omitempty"`
+		Availability string        `json:"availability,omitempty"`
+		Position     *czmlPosition `json:"position,omitempty"`
+		Label        *czmlLabel    `json:"label,omitempty"`
+		Point        *czmlPoint    `json:"point,omitempty"`
+		Polyline     *czmlPolyline `json:"polyline,omitempty"`
+		Polygon      *czmlPolygon  `json:"polygon,omitempty"`
+	}
+	czmlClock struct {
+		Interval    string  `json:"interval"`
+		CurrentTime string  `json:"currentTime"`
+		Multiplier  float64 `json:"multiplier"`
+	}
+	czmlPosition struct {
+		CartographicDegrees []float64 `json:"cartographicDegrees"`
+	}
+	czmlColor struct {
+		RGBA [4]uint8 `json:"rgba"`
+	}
+	czmlMaterial struct {
+		SolidColor struct {
+			Color czmlColor `json:"color"`
+		} `json:"solidColor"`
+	}
+	czmlLabel struct {
+		Text           string    `json:"text"`
+		FillColor      czmlColor `json:"fillColor"`
+		ShowBackground bool      `json:"showBackground"`
+	}
+	czmlPoint struct {
+		Color     czmlColor `json:"color"`
+		PixelSize float64   `json:"pixelSize"`
+	}
+	czmlPolyline struct {
+		Positions czmlPosition `json:"positions"`
+		Width     float64      `json:"width"`
+		Material  czmlMaterial `json:"material"`
+	}
+	czmlPolygon struct {
+		Positions         czmlPosition `json:"positions"`
+		PerPositionHeight bool         `json:"perPositionHeight"`
+		Material          czmlMaterial `json:"material"`
+	}
+)
+
+func newCZMLPosition(vertices ...mgl64.Vec3) (position czmlPosition) {
+	for _, vertex := range vertices {
+		position.CartographicDegrees = append(position.CartographicDegrees, vertex[0], vertex[1], vertex[2])
+	}
+	return
+}
+
+func newCZMLMaterial(color [4]uint8) (material czmlMaterial) {
+	material.SolidColor.Color.RGBA = color
+	return
+}
+
+func toCZMLInterval(begin time.Time, end time.Time) string {
+	return begin.UTC().Format(time.RFC3339Nano) + "/" + end.UTC().Format(time.RFC3339Nano)
+}
+
+// WriteCZML writes the measurements into a CZML document with the entities of each.
+// The entities are the faces of the convex hulls, and the segment of the closest points labeled with the distance.
+// If the measurements are time-tagged in order of Time, their entities are available until the next ones,
+// and the clock of the document plays them through.
+func WriteCZML(writer io.Writer, geodetics []Geodetic) error {
+	document := czmlPacket{
+		ID:      "document",
+		Name:    "closest",
+		Version: "1.0",
+	}
+	if len(geodetics) != 0 {
+		begin, _, ok := getInterval(geodetics, 0)
+		_, end, _ := getInterval(geodetics, len(geodetics)-1)
+		if ok {
+			document.Clock = &czmlClock{
+				Interval:    toCZMLInterval(begin, end),
+				CurrentTime: begin.UTC().Format(time.RFC3339Nano),
+				Multiplier:  1.0,
+			}
+		}
+	}
+	packets := []czmlPacket{document}
+
+	for i := range geodetics {
+		geodetic := &geodetics[i]
+		availability := ""
+		begin, end, ok := getInterval(geodetics, i)
+		if ok {
+			availability = toCZMLInterval(begin, end)
+		}
+
+		for j, convex := range geodetic.ConvexHulls {
+			id := fmt.Sprint(i, "/", geodeticStyles[j])
+			color := geodeticColors[j]
+			vertices, faces := newGeodeticHull(convex)
+			switch {
+			case len(faces) != 0:
+				for k, face := range faces {
+					packets = append(packets, czmlPacket{
+						ID:           fmt.Sprint(id, "/", k),
+						Name:         geodeticStyles[j],
+						Availability: availability,
+						Polygon: &czmlPolygon{
+							Positions:         newCZMLPosition(vertices[face[0]], vertices[face[1]], vertices[face[2]]),
+							PerPositionHeight: true,
+							Material:          newCZMLMaterial(color),
+						},
+					})
+				}
+			case len(vertices) > 1:
+				packets = append(packets, czmlPacket{
+					ID:           id,
+					Name:         geodeticStyles[j],
+					Availability: availability,
+					Polyline: &czmlPolyline{
+						Positions: newCZMLPosition(vertices...),
+						Width:     2.0,
+						Material:  newCZMLMaterial(color),
+					},
+				})
+			case len(vertices) == 1:
+				position := newCZMLPosition(vertices...)
+				packets = append(packets, czmlPacket{
+					ID:           id,
+					Name:         geodeticStyles[j],
+					Availability: availability,
+					Position:     &position,
+					Point:        &czmlPoint{Color: czmlColor{RGBA: color}, PixelSize: 8.0},
+				})
+			}
+		}
+
+		// The label is at the middle of the segment.
+		position := newCZMLPosition(geodetic.getMidpoint())
+		packets = append(packets, czmlPacket{
+			ID:           fmt.Sprint(i, "/segment"),
+			Name:         "segment",
+			Availability: availability,
+			Position:     &position,
+			Label: &czmlLabel{
+				Text:           geodetic.getLabel(),
+				FillColor:      czmlColor{RGBA: [4]uint8{255, 255, 255, 255}},
+				ShowBackground: true,
+			},
+			Polyline: &czmlPolyline{
+				Positions: newCZMLPosition(geodetic.Points[0], geodetic.Points[1]),
+				Width:     2.0,
+				Material:  newCZMLMaterial(geodeticColors[2]),
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(packets)
+}
@@ -0,0 +1,56 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteCZML(t *testing.T) {
+	geodetics := newTestGeodetics(2)
+
+	buffer := bytes.Buffer{}
+	err := WriteCZML(&buffer, geodetics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packets := []czmlPacket{}
+	err = json.Unmarshal(buffer.Bytes(), &packets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The document, and 4 faces of each tetrahedron and the segment for each measurement
+	if len(packets) != 1+2*9 || packets[0].ID != "document" || packets[0].Clock == nil {
+		t.Fatal(buffer.String())
+	}
+	if packets[0].Clock.Interval != "2024-01-02T03:04:05Z/2024-01-02T03:04:07Z" {
+		t.Error(packets[0].Clock)
+	}
+
+	labels := []string{}
+	for _, packet := range packets[1:] {
+		if packet.Availability == "" {
+			t.Error("No availability: ", packet.ID)
+		}
+		if packet.Polygon != nil && len(packet.Polygon.Positions.CartographicDegrees) != 9 {
+			t.Error(packet.ID, ": ", packet.Polygon.Positions)
+		}
+		if packet.Label != nil {
+			labels = append(labels, packet.ID+" "+packet.Label.Text)
+		}
+	}
+	if strings.Join(labels, ", ") != "0/segment Distance: 100.00 m, 1/segment Distance: 90.00 m" {
+		t.Error(labels)
+	}
+
+	buffer.Reset()
+	err = WriteCZML(&buffer, []Geodetic{{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buffer.String(), "clock") || strings.Contains(buffer.String(), "availability") {
+		t.Error("The measurement without Time is time-tagged: ", buffer.String())
+	}
+}
@@ -0,0 +1,147 @@
+package closestio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// geoJSON is any object of GeoJSON.
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Features    []geoJSON       `json:"features"`
+	Geometry    *geoJSON        `json:"geometry"`
+	Geometries  []geoJSON       `json:"geometries"`
+	Properties  map[string]any  `json:"properties"`
+	ID          any             `json:"id"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ReadGeoJSON reads the extrusions of the Polygon and MultiPolygon features of a GeoJSON FeatureCollection or Feature.
+// Each polygon is an [Extrusion] between the properties minAltitude and maxAltitude of its feature.
+// The other geometries are ignored.
+func ReadGeoJSON(reader io.Reader) (extrusions []Extrusion, err error) {
+	object := geoJSON{}
+	err = json.NewDecoder(reader).Decode(&object)
+	if err != nil {
+		return
+	}
+
+	features := []geoJSON{}
+	switch object.Type {
+	case "FeatureCollection":
+		features = object.Features
+	case "Feature":
+		features = append(features, object)
+	default:
+		return nil, fmt.Errorf("closestio: %q is not a feature", object.Type)
+	}
+
+	for i, feature := range features {
+		featureExtrusions, err := readGeoJSONFeature(feature)
+		if err != nil {
+			return nil, fmt.Errorf("closestio: feature %d: %w", i, err)
+		}
+		extrusions = append(extrusions, featureExtrusions...)
+	}
+	return
+}
+
+func readGeoJSONFeature(feature geoJSON) (extrusions []Extrusion, err error) {
+	if feature.Geometry == nil {
+		return
+	}
+
+	polygons, err := readGeoJSONPolygons(*feature.Geometry)
+	if err != nil || len(polygons) == 0 {
+		return
+	}
+
+	template := Extrusion{}
+	for _, key := range []string{"minAltitude", "maxAltitude"} {
+		altitude, ok := feature.Properties[key].(float64)
+		if !ok {
+			return nil, fmt.Errorf("the number %s is required", key)
+		}
+		if key == "minAltitude" {
+			template.MinAltitude = altitude
+		} else {
+			template.MaxAltitude = altitude
+		}
+	}
+	switch {
+	case feature.Properties["name"] != nil:
+		template.Name = fmt.Sprint(feature.Properties["name"])
+	case feature.ID != nil:
+		template.Name = fmt.Sprint(feature.ID)
+	}
+
+	for _, rings := range polygons {
+		extrusion := template
+		extrusion.Rings = rings
+		extrusions = append(extrusions, extrusion)
+	}
+	return
+}
+
+// readGeoJSONPolygons returns the rings of the polygons of geometry.
+func readGeoJSONPolygons(geometry geoJSON) (polygons [][][]mgl64.Vec2, err error) {
+	switch geometry.Type {
+	case "Polygon":
+		coordinates := [][][]float64{}
+		err = json.Unmarshal(geometry.Coordinates, &coordinates)
+		if err != nil {
+			return
+		}
+		rings, err := toRings(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		polygons = append(polygons, rings)
+	case "MultiPolygon":
+		coordinates := [][][][]float64{}
+		err = json.Unmarshal(geometry.Coordinates, &coordinates)
+		if err != nil {
+			return
+		}
+		for _, polygon := range coordinates {
+			rings, err := toRings(polygon)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, rings)
+		}
+	case "GeometryCollection":
+		for _, child := range geometry.Geometries {
+			childPolygons, err := readGeoJSONPolygons(child)
+			if err != nil {
+				return nil, err
+			}
+			polygons = append(polygons, childPolygons...)
+		}
+	}
+
+	return
+}
+
+// toRings converts the positions into the rings, ignoring the altitudes of the positions.
+func toRings(coordinates [][][]float64) (rings [][]mgl64.Vec2, err error) {
+	if len(coordinates) == 0 {
+		return nil, errors.New("a polygon has no rings")
+	}
+
+	for _, ring := range coordinates {
+		points := make([]mgl64.Vec2, len(ring))
+		for i, position := range ring {
+			if len(position) < 2 {
+				return nil, errors.New("a position has less than 2 coordinates")
+			}
+			points[i] = mgl64.Vec2{position[0], position[1]}
+		}
+		rings = append(rings, points)
+	}
+	return
+}
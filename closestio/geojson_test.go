@@ -0,0 +1,69 @@
+package closestio
+
+import (
+	"strings"
+	"testing"
+)
+
+const testAirspaceGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "id": 7,
+      "geometry": {
+        "type": "Polygon",
+        "coordinates": [[[139.0, 35.0], [139.02, 35.0], [139.02, 35.01], [139.01, 35.01], [139.01, 35.02], [139.0, 35.02], [139.0, 35.0]]]
+      },
+      "properties": {"minAltitude": 0, "maxAltitude": 150}
+    },
+    {
+      "type": "Feature",
+      "geometry": {
+        "type": "MultiPolygon",
+        "coordinates": [
+          [[[139.1, 35.0, 0], [139.11, 35.0, 0], [139.11, 35.01, 0], [139.1, 35.0, 0]]],
+          [[[139.2, 35.0], [139.21, 35.0], [139.21, 35.01], [139.2, 35.0]]]
+        ]
+      },
+      "properties": {"name": "Heliport", "minAltitude": 50.5, "maxAltitude": 300}
+    },
+    {
+      "type": "Feature",
+      "geometry": {"type": "Point", "coordinates": [139.0, 35.0]},
+      "properties": {}
+    }
+  ]
+}`
+
+func TestReadGeoJSON(t *testing.T) {
+	extrusions, err := ReadGeoJSON(strings.NewReader(testAirspaceGeoJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(extrusions) != 3 {
+		t.Fatal(extrusions)
+	}
+	if extrusions[0].Name != "7" || len(extrusions[0].Rings[0]) != 7 || extrusions[0].MaxAltitude != 150.0 {
+		t.Error(extrusions[0])
+	}
+	for _, extrusion := range extrusions[1:] {
+		if extrusion.Name != "Heliport" || extrusion.MinAltitude != 50.5 || extrusion.MaxAltitude != 300.0 {
+			t.Error(extrusion)
+		}
+	}
+}
+
+func TestReadGeoJSON_Error(t *testing.T) {
+	for _, text := range []string{
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`,
+		`{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}, "properties": {"minAltitude": 0}}`,
+		`{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[0], [1, 0], [1, 1], [0, 0]]]}, "properties": {"minAltitude": 0, "maxAltitude": 1}}`,
+		`{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": []}, "properties": {"minAltitude": 0, "maxAltitude": 1}}`,
+	} {
+		_, err := ReadGeoJSON(strings.NewReader(text))
+		if err == nil {
+			t.Error("Accepted: ", text)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package closestio
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// The subset of KML 2.2
+type (
+	kml struct {
+		XMLName   xml.Name    `xml:"kml"`
+		Namespace string      `xml:"xmlns,attr"`
+		Document  kmlDocument `xml:"Document"`
+	}
+	kmlDocument struct {
+		Name    string      `xml:"name"`
+		Styles  []kmlStyle  `xml:"Style"`
+		Folders []kmlFolder `xml:"Folder"`
+	}
+	kmlStyle struct {
+		ID        string       `xml:"id,attr"`
+		LineStyle kmlLineStyle `xml:"LineStyle"`
+		PolyStyle kmlPolyStyle `xml:"PolyStyle"`
+	}
+	kmlLineStyle struct {
+		Color string  `xml:"color"`
+		Width float64 `xml:"width"`
+	}
+	kmlPolyStyle struct {
+		Color string `xml:"color"`
+	}
+	kmlFolder struct {
+		Name       string         `xml:"name"`
+		TimeSpan   *kmlTimeSpan   `xml:"TimeSpan,omitempty"`
+		Placemarks []kmlPlacemark `xml:"Placemark"`
+	}
+	kmlTimeSpan struct {
+		Begin string `xml:"begin"`
+		End   string `xml:"end"`
+	}
+	kmlPlacemark struct {
+		Name          string           `xml:"name"`
+		StyleURL      string           `xml:"styleUrl"`
+		MultiGeometry kmlMultiGeometry `xml:"MultiGeometry"`
+	}
+	kmlMultiGeometry struct {
+		Points      []kmlGeometry `xml:"Point"`
+		LineStrings []kmlGeometry `xml:"LineString"`
+		Polygons    []kmlPolygon  `xml:"Polygon"`
+	}
+	kmlGeometry struct {
+		AltitudeMode string `xml:"altitudeMode"`
+		Coordinates  string `xml:"coordinates"`
+	}
+	kmlPolygon struct {
+		AltitudeMode    string      `xml:"altitudeMode"`
+		OuterBoundaryIs kmlBoundary `xml:"outerBoundaryIs"`
+	}
+	kmlBoundary struct {
+		LinearRing kmlLinearRing `xml:"LinearRing"`
+	}
+	kmlLinearRing struct {
+		Coordinates string `xml:"coordinates"`
+	}
+)
+
+// geodeticColors are the RGBA colors of the convex hulls and the segment.
+var geodeticColors = [...][4]uint8{
+	{51, 102, 255, 128},
+	{255, 128, 26, 128},
+	{255, 0, 0, 255},
+}
+
+var geodeticStyles = [...]string{"hull0", "hull1", "segment"}
+
+// toKMLColor returns the color in aabbggrr.
+func toKMLColor(color [4]uint8) string {
+	return fmt.Sprintf("%02x%02x%02x%02x", color[3], color[2], color[1], color[0])
+}
+
+func toKMLCoordinates(vertices ...mgl64.Vec3) string {
+	coordinates := make([]string, len(vertices))
+	for i, vertex := range vertices {
+		coordinates[i] = fmt.Sprint(vertex[0], ",", vertex[1], ",", vertex[2])
+	}
+	return strings.Join(coordinates, " ")
+}
+
+// WriteKML writes the measurements into a KML document with a folder of each.
+// A folder has the faces of the convex hulls, and the segment of the closest points labeled with the distance.
+// The folders of the time-tagged measurements in order of Time last until the next ones.
+// The heights are written as the absolute altitudes.
+func WriteKML(writer io.Writer, geodetics []Geodetic) error {
+	document := kml{
+		Namespace: "http://www.opengis.net/kml/2.2",
+		Document:  kmlDocument{Name: "closest"},
+	}
+	for i, name := range geodeticStyles {
+		color := toKMLColor(geodeticColors[i])
+		document.Document.Styles = append(document.Document.Styles, kmlStyle{
+			ID:        name,
+			LineStyle: kmlLineStyle{Color: color, Width: 2.0},
+			PolyStyle: kmlPolyStyle{Color: color},
+		})
+	}
+
+	for i := range geodetics {
+		geodetic := &geodetics[i]
+		folder := kmlFolder{Name: fmt.Sprint("measurement", i)}
+		begin, end, ok := getInterval(geodetics, i)
+		if ok {
+			folder.Name = begin.UTC().Format(time.RFC3339Nano)
+			folder.TimeSpan = &kmlTimeSpan{
+				Begin: begin.UTC().Format(time.RFC3339Nano),
+				End:   end.UTC().Format(time.RFC3339Nano),
+			}
+		}
+
+		for j, convex := range geodetic.ConvexHulls {
+			placemark := kmlPlacemark{
+				Name:     geodeticStyles[j],
+				StyleURL: "#" + geodeticStyles[j],
+			}
+			geometry := &placemark.MultiGeometry
+			vertices, faces := newGeodeticHull(convex)
+			switch {
+			case len(faces) != 0:
+				for _, face := range faces {
+					geometry.Polygons = append(geometry.Polygons, kmlPolygon{
+						AltitudeMode: "absolute",
+						OuterBoundaryIs: kmlBoundary{LinearRing: kmlLinearRing{
+							Coordinates: toKMLCoordinates(vertices[face[0]], vertices[face[1]], vertices[face[2]], vertices[face[0]]),
+						}},
+					})
+				}
+			case len(vertices) > 1:
+				geometry.LineStrings = append(geometry.LineStrings, kmlGeometry{
+					AltitudeMode: "absolute",
+					Coordinates:  toKMLCoordinates(vertices...),
+				})
+			case len(vertices) == 1:
+				geometry.Points = append(geometry.Points, kmlGeometry{
+					AltitudeMode: "absolute",
+					Coordinates:  toKMLCoordinates(vertices...),
+				})
+			}
+			folder.Placemarks = append(folder.Placemarks, placemark)
+		}
+
+		// The label is at the middle of the segment.
+		folder.Placemarks = append(folder.Placemarks, kmlPlacemark{
+			Name:     geodetic.getLabel(),
+			StyleURL: "#segment",
+			MultiGeometry: kmlMultiGeometry{
+				Points: []kmlGeometry{{
+					AltitudeMode: "absolute",
+					Coordinates:  toKMLCoordinates(geodetic.getMidpoint()),
+				}},
+				LineStrings: []kmlGeometry{{
+					AltitudeMode: "absolute",
+					Coordinates:  toKMLCoordinates(geodetic.Points[0], geodetic.Points[1]),
+				}},
+			},
+		})
+
+		document.Document.Folders = append(document.Document.Folders, folder)
+	}
+
+	_, err := io.WriteString(writer, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(document)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
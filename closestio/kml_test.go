@@ -0,0 +1,42 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestWriteKML(t *testing.T) {
+	geodetics := newTestGeodetics(3)
+
+	buffer := bytes.Buffer{}
+	err := WriteKML(&buffer, geodetics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	document := kml{}
+	err = xml.Unmarshal(buffer.Bytes(), &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	folders := document.Document.Folders
+	if len(folders) != 3 || len(document.Document.Styles) != len(geodeticStyles) {
+		t.Fatal(buffer.String())
+	}
+	for _, folder := range folders {
+		if folder.TimeSpan == nil || folder.TimeSpan.Begin != folder.Name || len(folder.Placemarks) != 3 {
+			t.Error(folder)
+		}
+	}
+	if folders[0].TimeSpan.End != folders[1].TimeSpan.Begin {
+		t.Error(folders[0].TimeSpan, folders[1].TimeSpan)
+	}
+	if polygons := folders[0].Placemarks[0].MultiGeometry.Polygons; len(polygons) != 4 {
+		t.Error(polygons)
+	}
+	segment := folders[0].Placemarks[2]
+	if segment.Name != "Distance: 100.00 m" || len(segment.MultiGeometry.LineStrings) != 1 {
+		t.Error(segment)
+	}
+}
@@ -0,0 +1,92 @@
+package closestio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ReadOBJ reads the objects of a Wavefront OBJ file. Each object (o) or group (g) is an [Object],
+// and the groups of the same name are merged. The polygons are divided into triangle fans.
+// If the file has no faces, the vertices are returned as an object without triangles.
+func ReadOBJ(reader io.Reader, options ReadOptions) (objects []Object, err error) {
+	vertices := []mgl64.Vec3{}
+	builders := []*objectBuilder{}
+	builderByName := map[string]*objectBuilder{}
+	var current *objectBuilder
+	use := func(name string) {
+		current = builderByName[name]
+		if current == nil {
+			current = newObjectBuilder(name)
+			builders = append(builders, current)
+			builderByName[name] = current
+		}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line += 1 {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("line %d: 3 coordinates are required", line)
+			}
+			vertex := mgl64.Vec3{}
+			for i := 0; i < 3; i += 1 {
+				vertex[i], err = strconv.ParseFloat(fields[i+1], 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+			}
+			vertices = append(vertices, options.convert(vertex))
+		case "o", "g":
+			use(strings.Join(fields[1:], " "))
+		case "f":
+			if current == nil {
+				use("")
+			}
+			polygon := make([]int, len(fields)-1)
+			for i, field := range fields[1:] {
+				reference, _, _ := strings.Cut(field, "/")
+				index, err := strconv.Atoi(reference)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				if index < 0 {
+					// Relative to the last vertex
+					index += len(vertices)
+				} else {
+					index -= 1
+				}
+				if index < 0 || index >= len(vertices) {
+					return nil, fmt.Errorf("line %d: vertex %s out of range", line, reference)
+				}
+				polygon[i] = index
+			}
+			current.addPolygon(vertices, polygon)
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, builder := range builders {
+		if len(builder.object.Triangles) != 0 {
+			objects = append(objects, builder.object)
+		}
+	}
+	if len(objects) == 0 && len(vertices) != 0 {
+		objects = append(objects, newPointCloud("", vertices))
+	}
+	return
+}
@@ -0,0 +1,94 @@
+package closestio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// testCubeOBJ has two cubes of the size 1 at the origin and at x = 3, and a quad group.
+const testCubeOBJ = `# Two cubes
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+v 0 0 1
+v 1 0 1
+v 1 1 1
+v 0 1 1
+o near
+f 1 4 3 2
+f 5 6 7 8
+f 1 2 6 5
+f 2/1 3/2 7/3 6/4
+f 3//1 4//1 8//1 7//1
+f 4 1 5 8
+v 3 0 0
+v 4 0 0
+v 4 1 0
+v 3 1 0
+v 3 0 1
+v 4 0 1
+v 4 1 1
+v 3 1 1
+o far
+f -8 -5 -6 -7
+f -4 -3 -2 -1
+f -8 -7 -3 -4
+f -7 -6 -2 -3
+f -6 -5 -1 -2
+f -5 -8 -4 -1
+`
+
+func TestReadOBJ(t *testing.T) {
+	objects, err := ReadOBJ(strings.NewReader(testCubeOBJ), ReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 || objects[0].Name != "near" || objects[1].Name != "far" {
+		t.Fatal(objects)
+	}
+	for _, object := range objects {
+		if len(object.Vertices) != 8 || len(object.Triangles) != 12 {
+			t.Error(object.Name, ": ", len(object.Vertices), " vertices, ", len(object.Triangles), " triangles")
+		}
+	}
+	min, max := objects[1].Bounds()
+	if min != (mgl64.Vec3{3.0, 0.0, 0.0}) || max != (mgl64.Vec3{4.0, 1.0, 1.0}) {
+		t.Error(min, max)
+	}
+
+	measure := closest.Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{objects[0].Vertices, objects[1].Vertices},
+	}
+	measure.MeasureDistance()
+	if measure.Distance != 2.0 {
+		t.Error(measure.Distance)
+	}
+}
+
+func TestReadOBJ_PointCloud(t *testing.T) {
+	objects, err := ReadOBJ(strings.NewReader("v 1 2 3\nv 4 5 6\n"), ReadOptions{Scale: 2.0, Axes: YUp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || len(objects[0].Vertices) != 2 || *objects[0].Vertices[1] != (mgl64.Vec3{8.0, -12.0, 10.0}) {
+		t.Error(objects)
+	}
+}
+
+func TestReadOBJ_Error(t *testing.T) {
+	for _, content := range []string{
+		"v 0 0\n",
+		"v 0 0 a\n",
+		"v 0 0 0\nf 1 2 3\n",
+		"v 0 0 0\nf 1 1 -2\n",
+	} {
+		_, err := ReadOBJ(strings.NewReader(content), ReadOptions{})
+		if err == nil {
+			t.Error("Accepted: ", content)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package closestio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// plyProperty is a property of an element of a PLY file.
+type plyProperty struct {
+	name      string
+	valueType string
+	countType string // Empty if the property is not a list
+}
+
+// plyElement is an element of a PLY file, such as vertex or face.
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+// plyValueReader reads a value of a type of PLY.
+type plyValueReader func(valueType string) (float64, error)
+
+// ReadPLY reads the object of an ASCII or binary PLY file.
+// The vertices are by the properties x, y and z of the vertex element,
+// and the faces are by the list property vertex_indices or vertex_index of the face element.
+// If the file has no faces, the vertices are returned as an object without triangles.
+func ReadPLY(reader io.Reader, options ReadOptions) ([]Object, error) {
+	bufferedReader := bufio.NewReader(reader)
+	format, elements, err := readPLYHeader(bufferedReader)
+	if err != nil {
+		return nil, err
+	}
+
+	var readValue plyValueReader
+	switch format {
+	case "ascii":
+		scanner := bufio.NewScanner(bufferedReader)
+		scanner.Split(bufio.ScanWords)
+		readValue = func(valueType string) (float64, error) {
+			if !scanner.Scan() {
+				if scanner.Err() != nil {
+					return 0.0, scanner.Err()
+				}
+				return 0.0, io.ErrUnexpectedEOF
+			}
+			return strconv.ParseFloat(scanner.Text(), 64)
+		}
+	case "binary_little_endian":
+		readValue = newPLYBinaryReader(bufferedReader, binary.LittleEndian)
+	case "binary_big_endian":
+		readValue = newPLYBinaryReader(bufferedReader, binary.BigEndian)
+	default:
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+
+	vertices := []mgl64.Vec3{}
+	builder := newObjectBuilder("")
+	for _, element := range elements {
+		for i := 0; i < element.count; i += 1 {
+			vertex := mgl64.Vec3{}
+			polygon := []int{}
+			for _, property := range element.properties {
+				values := []float64{}
+				if property.countType == "" {
+					value, err := readValue(property.valueType)
+					if err != nil {
+						return nil, fmt.Errorf("%s %d: %w", element.name, i, err)
+					}
+					values = append(values, value)
+				} else {
+					count, err := readValue(property.countType)
+					if err != nil {
+						return nil, fmt.Errorf("%s %d: %w", element.name, i, err)
+					}
+					for j := 0; j < int(count); j += 1 {
+						value, err := readValue(property.valueType)
+						if err != nil {
+							return nil, fmt.Errorf("%s %d: %w", element.name, i, err)
+						}
+						values = append(values, value)
+					}
+				}
+
+				switch {
+				case element.name == "vertex" && property.countType == "":
+					switch property.name {
+					case "x":
+						vertex[0] = values[0]
+					case "y":
+						vertex[1] = values[0]
+					case "z":
+						vertex[2] = values[0]
+					}
+				case element.name == "face" && (property.name == "vertex_indices" || property.name == "vertex_index"):
+					for _, value := range values {
+						polygon = append(polygon, int(value))
+					}
+				}
+			}
+
+			switch element.name {
+			case "vertex":
+				vertices = append(vertices, options.convert(vertex))
+			case "face":
+				for _, index := range polygon {
+					if index < 0 || index >= len(vertices) {
+						return nil, fmt.Errorf("face %d: vertex %d out of range", i, index)
+					}
+				}
+				builder.addPolygon(vertices, polygon)
+			}
+		}
+	}
+
+	if len(builder.object.Triangles) == 0 {
+		return []Object{newPointCloud("", vertices)}, nil
+	}
+	return []Object{builder.object}, nil
+}
+
+// readPLYHeader reads the header through end_header.
+func readPLYHeader(reader *bufio.Reader) (format string, elements []plyElement, err error) {
+	for line := 1; ; line += 1 {
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return "", nil, fmt.Errorf("header: %w", err)
+		}
+		fields := strings.Fields(text)
+		if line == 1 {
+			if len(fields) != 1 || fields[0] != "ply" {
+				return "", nil, errors.New("not a PLY")
+			}
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "format":
+			if len(fields) != 3 {
+				return "", nil, fmt.Errorf("line %d: invalid format", line)
+			}
+			format = fields[1]
+		case "element":
+			if len(fields) != 3 {
+				return "", nil, fmt.Errorf("line %d: invalid element", line)
+			}
+			count, err := strconv.Atoi(fields[2])
+			if err != nil || count < 0 {
+				return "", nil, fmt.Errorf("line %d: invalid count", line)
+			}
+			elements = append(elements, plyElement{
+				name:  fields[1],
+				count: count,
+			})
+		case "property":
+			if len(elements) == 0 {
+				return "", nil, fmt.Errorf("line %d: property out of element", line)
+			}
+			property := plyProperty{}
+			switch {
+			case len(fields) == 3:
+				property.valueType = fields[1]
+				property.name = fields[2]
+			case len(fields) == 5 && fields[1] == "list":
+				property.countType = fields[2]
+				property.valueType = fields[3]
+				property.name = fields[4]
+			default:
+				return "", nil, fmt.Errorf("line %d: invalid property", line)
+			}
+			for _, valueType := range []string{property.countType, property.valueType} {
+				if valueType != "" && getPLYSize(valueType) == 0 {
+					return "", nil, fmt.Errorf("line %d: unknown type: %s", line, valueType)
+				}
+			}
+			elements[len(elements)-1].properties = append(elements[len(elements)-1].properties, property)
+		case "end_header":
+			return format, elements, nil
+		}
+	}
+}
+
+// getPLYSize returns the size of valueType in bytes, or 0 if it is unknown.
+func getPLYSize(valueType string) int {
+	switch valueType {
+	case "char", "int8", "uchar", "uint8":
+		return 1
+	case "short", "int16", "ushort", "uint16":
+		return 2
+	case "int", "int32", "uint", "uint32", "float", "float32":
+		return 4
+	case "double", "float64":
+		return 8
+	default:
+		return 0
+	}
+}
+
+func newPLYBinaryReader(reader io.Reader, order binary.ByteOrder) plyValueReader {
+	buffer := make([]byte, 8)
+	return func(valueType string) (float64, error) {
+		data := buffer[:getPLYSize(valueType)]
+		_, err := io.ReadFull(reader, data)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0.0, err
+		}
+
+		switch valueType {
+		case "char", "int8":
+			return float64(int8(data[0])), nil
+		case "uchar", "uint8":
+			return float64(data[0]), nil
+		case "short", "int16":
+			return float64(int16(order.Uint16(data))), nil
+		case "ushort", "uint16":
+			return float64(order.Uint16(data)), nil
+		case "int", "int32":
+			return float64(int32(order.Uint32(data))), nil
+		case "uint", "uint32":
+			return float64(order.Uint32(data)), nil
+		case "float", "float32":
+			return float64(math.Float32frombits(order.Uint32(data))), nil
+		default:
+			return math.Float64frombits(order.Uint64(data)), nil
+		}
+	}
+}
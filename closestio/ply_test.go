@@ -0,0 +1,92 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+const testPLYHeader = `ply
+format %s 1.0
+comment A tetrahedron
+element vertex 4
+property float x
+property float y
+property float z
+property uchar red
+element face 4
+property list uchar int vertex_indices
+end_header
+`
+
+var testPLYVertices = []mgl64.Vec3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+var testPLYFaces = [][3]int{{0, 2, 1}, {0, 1, 3}, {0, 3, 2}, {1, 2, 3}}
+
+// newTestPLY makes a tetrahedron in format. order is nil for ascii.
+func newTestPLY(format string, order binary.ByteOrder) []byte {
+	buffer := bytes.NewBufferString(fmt.Sprintf(testPLYHeader, format))
+	if order == nil {
+		for _, vertex := range testPLYVertices {
+			fmt.Fprintf(buffer, "%v %v %v 255\n", vertex[0], vertex[1], vertex[2])
+		}
+		for _, face := range testPLYFaces {
+			fmt.Fprintf(buffer, "3 %d %d %d\n", face[0], face[1], face[2])
+		}
+		return buffer.Bytes()
+	}
+
+	for _, vertex := range testPLYVertices {
+		for k := 0; k < 3; k += 1 {
+			binary.Write(buffer, order, float32(vertex[k]))
+		}
+		buffer.WriteByte(255)
+	}
+	for _, face := range testPLYFaces {
+		buffer.WriteByte(3)
+		for _, index := range face {
+			binary.Write(buffer, order, uint32(index))
+		}
+	}
+	return buffer.Bytes()
+}
+
+func TestReadPLY(t *testing.T) {
+	for format, order := range map[string]binary.ByteOrder{
+		"ascii":                nil,
+		"binary_little_endian": binary.LittleEndian,
+		"binary_big_endian":    binary.BigEndian,
+	} {
+		objects, err := ReadPLY(bytes.NewReader(newTestPLY(format, order)), ReadOptions{Axes: YUp})
+		if err != nil {
+			t.Fatal(format, ": ", err)
+		}
+		if len(objects) != 1 || len(objects[0].Vertices) != 4 || len(objects[0].Triangles) != 4 {
+			t.Fatal(format, ": ", objects)
+		}
+		// The y axis up is converted into the z axis up.
+		min, max := objects[0].Bounds()
+		if min != (mgl64.Vec3{0.0, -1.0, 0.0}) || max != (mgl64.Vec3{1.0, 0.0, 1.0}) {
+			t.Error(format, ": ", min, max)
+		}
+	}
+}
+
+func TestReadPLY_Error(t *testing.T) {
+	for _, data := range [][]byte{
+		[]byte("obj\n"),
+		[]byte("ply\nformat ascii 1.0\nelement vertex 1\nproperty quad x\nend_header\n"),
+		[]byte("ply\nformat ascii 1.0\nelement vertex 2\nproperty float x\nend_header\n0\n"),
+		[]byte(strings.Replace(string(newTestPLY("ascii", nil)), "3 1 2 3", "3 1 2 4", 1)),
+		newTestPLY("binary_little_endian", binary.LittleEndian)[:200],
+	} {
+		_, err := ReadPLY(bytes.NewReader(data), ReadOptions{})
+		if err == nil {
+			t.Error("Accepted: ", string(data))
+		}
+	}
+}
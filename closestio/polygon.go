@@ -0,0 +1,317 @@
+package closestio
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// cross2D returns the z component of (b - a) × (c - a), which is positive if a, b and c are counterclockwise.
+func cross2D(a, b, c mgl64.Vec2) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func getSignedArea(points []mgl64.Vec2, ring []int) (area float64) {
+	for i, index := range ring {
+		a := points[index]
+		b := points[ring[(i+1)%len(ring)]]
+		area += a[0]*b[1] - a[1]*b[0]
+	}
+	return 0.5 * area
+}
+
+// normalizeRings returns the points of rings, and the rings by their indices
+// without the closing points and the consecutive duplicates.
+// The first ring is counterclockwise, and the others are clockwise.
+func normalizeRings(rings [][]mgl64.Vec2) (points []mgl64.Vec2, indexRings [][]int, err error) {
+	if len(rings) == 0 {
+		return nil, nil, errors.New("the polygon has no rings")
+	}
+
+	for i, ring := range rings {
+		indexRing := []int{}
+		for _, point := range ring {
+			if math.IsNaN(point[0]) || math.IsInf(point[0], 0) || math.IsNaN(point[1]) || math.IsInf(point[1], 0) {
+				return nil, nil, errors.New("a point is not finite")
+			}
+			if len(indexRing) != 0 && points[indexRing[len(indexRing)-1]] == point {
+				continue
+			}
+			indexRing = append(indexRing, len(points))
+			points = append(points, point)
+		}
+		for len(indexRing) > 1 && points[indexRing[0]] == points[indexRing[len(indexRing)-1]] {
+			indexRing = indexRing[:len(indexRing)-1]
+		}
+		if len(indexRing) < 3 {
+			if i == 0 {
+				return nil, nil, errors.New("the outer ring has less than 3 points")
+			}
+			// The degenerate hole is ignored.
+			continue
+		}
+
+		area := getSignedArea(points, indexRing)
+		if area == 0.0 {
+			if i == 0 {
+				return nil, nil, errors.New("the outer ring has no area")
+			}
+			continue
+		}
+		if (i == 0) != (area > 0.0) {
+			for j, k := 0, len(indexRing)-1; j < k; j, k = j+1, k-1 {
+				indexRing[j], indexRing[k] = indexRing[k], indexRing[j]
+			}
+		}
+		indexRings = append(indexRings, indexRing)
+	}
+
+	return
+}
+
+// bridgeHoles connects the holes to the outer ring by the bridges of two opposite edges,
+// and returns the polygon of a ring. The bridge vertex is found by the ray to +x by Eberly.
+func bridgeHoles(points []mgl64.Vec2, rings [][]int) (polygon []int) {
+	polygon = append(polygon, rings[0]...)
+
+	holes := append([][]int{}, rings[1:]...)
+	getMaxX := func(hole []int) (maxI int) {
+		for i, index := range hole {
+			if points[index][0] > points[hole[maxI]][0] {
+				maxI = i
+			}
+		}
+		return
+	}
+	sort.Slice(holes, func(i int, j int) bool {
+		return points[holes[i][getMaxX(holes[i])]][0] > points[holes[j][getMaxX(holes[j])]][0]
+	})
+
+	for _, hole := range holes {
+		holeI := getMaxX(hole)
+		m := points[hole[holeI]]
+
+		// The closest intersection of the ray from m to +x with the edges of the polygon
+		bridgeI := -1
+		intersection := mgl64.Vec2{math.Inf(1), m[1]}
+		for i, index := range polygon {
+			a := points[index]
+			b := points[polygon[(i+1)%len(polygon)]]
+			// The edges from below to above, since the polygon is counterclockwise around the hole
+			if !(a[1] <= m[1] && m[1] <= b[1]) || a[1] == b[1] {
+				continue
+			}
+			x := a[0] + (m[1]-a[1])*(b[0]-a[0])/(b[1]-a[1])
+			if x < m[0] || x >= intersection[0] {
+				continue
+			}
+			intersection[0] = x
+			bridgeI = i
+			if b[0] > a[0] {
+				bridgeI = (i + 1) % len(polygon)
+			}
+		}
+		if bridgeI == -1 {
+			// The hole is out of the outer ring.
+			continue
+		}
+
+		// The reflex vertices in the triangle of m, the intersection and the candidate block the bridge,
+		// so the one of the least angle to the ray is chosen instead.
+		p := points[polygon[bridgeI]]
+		if p != intersection {
+			minTangent := math.Inf(1)
+			for i, index := range polygon {
+				point := points[index]
+				if i == bridgeI || point == m {
+					continue
+				}
+				previous := points[polygon[(i+len(polygon)-1)%len(polygon)]]
+				next := points[polygon[(i+1)%len(polygon)]]
+				if cross2D(previous, point, next) >= 0.0 {
+					// Not reflex
+					continue
+				}
+				if !isInTriangle(point, m, intersection, p) {
+					continue
+				}
+				tangent := math.Abs(point[1]-m[1]) / (point[0] - m[0])
+				if tangent < minTangent || tangent == minTangent && point[0] < points[polygon[bridgeI]][0] {
+					minTangent = tangent
+					bridgeI = i
+				}
+			}
+		}
+
+		bridged := append([]int{}, polygon[:bridgeI+1]...)
+		for i := 0; i <= len(hole); i += 1 {
+			bridged = append(bridged, hole[(holeI+i)%len(hole)])
+		}
+		bridged = append(bridged, polygon[bridgeI:]...)
+		polygon = bridged
+	}
+
+	return
+}
+
+// isInTriangle reports whether point is in or on the triangle of a, b and c in any orientation.
+func isInTriangle(point, a, b, c mgl64.Vec2) bool {
+	d0 := cross2D(a, b, point)
+	d1 := cross2D(b, c, point)
+	d2 := cross2D(c, a, point)
+	hasNegative := d0 < 0.0 || d1 < 0.0 || d2 < 0.0
+	hasPositive := d0 > 0.0 || d1 > 0.0 || d2 > 0.0
+	return !(hasNegative && hasPositive)
+}
+
+// triangulate divides the counterclockwise polygon into counterclockwise triangles by ear clipping.
+// The collinear vertices are dropped.
+func triangulate(points []mgl64.Vec2, polygon []int) (triangles [][3]int, err error) {
+	remaining := append([]int{}, polygon...)
+	for len(remaining) > 3 {
+		isClipped := false
+		for i := 0; i < len(remaining); i += 1 {
+			a := remaining[(i+len(remaining)-1)%len(remaining)]
+			b := remaining[i]
+			c := remaining[(i+1)%len(remaining)]
+			cross := cross2D(points[a], points[b], points[c])
+			if cross < 0.0 {
+				// Reflex
+				continue
+			}
+			if cross > 0.0 && !isEar(points, remaining, a, b, c) {
+				continue
+			}
+
+			if cross > 0.0 {
+				triangles = append(triangles, [3]int{a, b, c})
+			}
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			isClipped = true
+			break
+		}
+		if !isClipped {
+			return nil, errors.New("the polygon intersects itself")
+		}
+	}
+	if cross2D(points[remaining[0]], points[remaining[1]], points[remaining[2]]) > 0.0 {
+		triangles = append(triangles, [3]int{remaining[0], remaining[1], remaining[2]})
+	}
+
+	return
+}
+
+// isEar reports whether no other vertex of polygon is in the triangle of a, b and c.
+func isEar(points []mgl64.Vec2, polygon []int, a, b, c int) bool {
+	for _, index := range polygon {
+		point := points[index]
+		if point == points[a] || point == points[b] || point == points[c] {
+			continue
+		}
+		if isInTriangle(point, points[a], points[b], points[c]) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeConvex merges the counterclockwise triangles across their shared edges as long as the merged
+// polygons are convex, by Hertel and Mehlhorn.
+func mergeConvex(points []mgl64.Vec2, triangles [][3]int) (polygons [][]int) {
+	for _, triangle := range triangles {
+		polygons = append(polygons, []int{triangle[0], triangle[1], triangle[2]})
+	}
+
+	for isMerged := true; isMerged; {
+		isMerged = false
+
+		edges := map[[2]int]int{} // The indices of polygons by their edges
+		for i, polygon := range polygons {
+			for j, index := range polygon {
+				edges[[2]int{index, polygon[(j+1)%len(polygon)]}] = i
+			}
+		}
+
+	loop:
+		for i, polygon := range polygons {
+			for j, index := range polygon {
+				next := polygon[(j+1)%len(polygon)]
+				k, ok := edges[[2]int{next, index}]
+				if !ok || k == i {
+					continue
+				}
+
+				merged := mergePolygons(polygon, j, polygons[k])
+				if !isConvex(points, merged) {
+					continue
+				}
+
+				polygons[i] = merged
+				polygons = append(polygons[:k], polygons[k+1:]...)
+				isMerged = true
+				break loop
+			}
+		}
+	}
+
+	return
+}
+
+// mergePolygons merges polygon and other sharing the edge from polygon[j] to its next in the opposite direction.
+func mergePolygons(polygon []int, j int, other []int) (merged []int) {
+	from := polygon[j]
+	to := polygon[(j+1)%len(polygon)]
+
+	// From to around polygon to from
+	for i := 1; i <= len(polygon); i += 1 {
+		merged = append(merged, polygon[(j+i)%len(polygon)])
+	}
+	// After from around other before to
+	start := 0
+	for other[start] != to || other[(start+1)%len(other)] != from {
+		start += 1
+	}
+	for i := 1; i < len(other)-1; i += 1 {
+		merged = append(merged, other[(start+1+i)%len(other)])
+	}
+
+	return
+}
+
+func isConvex(points []mgl64.Vec2, polygon []int) bool {
+	for i, index := range polygon {
+		previous := points[polygon[(i+len(polygon)-1)%len(polygon)]]
+		next := points[polygon[(i+1)%len(polygon)]]
+		if cross2D(previous, points[index], next) < 0.0 {
+			return false
+		}
+	}
+	return true
+}
+
+// decomposeConvex decomposes the polygon of the outer ring and the holes into convex polygons.
+func decomposeConvex(rings [][]mgl64.Vec2) (convexes [][]mgl64.Vec2, err error) {
+	points, indexRings, err := normalizeRings(rings)
+	if err != nil {
+		return
+	}
+
+	triangles, err := triangulate(points, bridgeHoles(points, indexRings))
+	if err != nil {
+		return
+	}
+	for _, polygon := range mergeConvex(points, triangles) {
+		convex := make([]mgl64.Vec2, len(polygon))
+		for i, index := range polygon {
+			convex[i] = points[index]
+		}
+		convexes = append(convexes, convex)
+	}
+	if len(convexes) == 0 {
+		return nil, errors.New("the polygon has no convex pieces")
+	}
+
+	return
+}
@@ -0,0 +1,146 @@
+package closestio
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// checkDecomposition checks that convexes are convex and cover the area of rings.
+func checkDecomposition(t *testing.T, rings [][]mgl64.Vec2, convexes [][]mgl64.Vec2) {
+	t.Helper()
+
+	area := 0.0
+	for _, ring := range rings {
+		points, indexRings, err := normalizeRings([][]mgl64.Vec2{ring})
+		if err != nil {
+			t.Fatal(err)
+		}
+		area += math.Abs(getSignedArea(points, indexRings[0]))
+	}
+	area = 2.0*math.Abs(getSignedAreaOf(rings[0])) - area
+
+	sum := 0.0
+	for _, convex := range convexes {
+		indices := make([]int, len(convex))
+		for i := range indices {
+			indices[i] = i
+		}
+		if !isConvex(convex, indices) {
+			t.Error("Not convex: ", convex)
+		}
+		sum += getSignedAreaOf(convex)
+	}
+	if math.Abs(sum-area) > 1e-9*area {
+		t.Error("Area: ", sum, " Expected: ", area)
+	}
+}
+
+func getSignedAreaOf(ring []mgl64.Vec2) float64 {
+	indices := make([]int, len(ring))
+	for i := range indices {
+		indices[i] = i
+	}
+	return getSignedArea(ring, indices)
+}
+
+func TestDecomposeConvex(t *testing.T) {
+	for _, testCase := range []struct {
+		name  string
+		rings [][]mgl64.Vec2
+		count int
+	}{
+		{
+			"Square",
+			[][]mgl64.Vec2{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}},
+			1,
+		},
+		{
+			"L",
+			[][]mgl64.Vec2{{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}},
+			2,
+		},
+		{
+			"Collinear",
+			[][]mgl64.Vec2{{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {0, 2}}},
+			1,
+		},
+		{
+			"Hole",
+			[][]mgl64.Vec2{
+				{{0, 0}, {4, 0}, {4, 4}, {0, 4}},
+				{{1, 1}, {3, 1}, {3, 3}, {1, 3}},
+			},
+			4,
+		},
+		{
+			"Holes",
+			[][]mgl64.Vec2{
+				{{0, 0}, {10, 0}, {10, 4}, {0, 4}},
+				{{1, 1}, {1, 3}, {3, 3}, {3, 1}},
+				{{6, 1}, {8, 1}, {7, 3}},
+			},
+			0,
+		},
+	} {
+		convexes, err := decomposeConvex(testCase.rings)
+		if err != nil {
+			t.Fatal(testCase.name, ": ", err)
+		}
+		if testCase.count != 0 && len(convexes) != testCase.count {
+			t.Error(testCase.name, ": ", len(convexes), " convex polygons: ", convexes)
+		}
+		checkDecomposition(t, testCase.rings, convexes)
+	}
+}
+
+func TestDecomposeConvexRandomly(t *testing.T) {
+	for seed := int64(0); seed < 200; seed += 1 {
+		random := rand.New(rand.NewSource(seed))
+
+		// A star-shaped polygon
+		count := random.Intn(20) + 3
+		ring := make([]mgl64.Vec2, count)
+		for i := range ring {
+			angle := 2.0 * math.Pi * (float64(i) + 0.8*random.Float64()) / float64(count)
+			radius := 0.2 + random.Float64()
+			ring[i] = mgl64.Vec2{radius * math.Cos(angle), radius * math.Sin(angle)}
+		}
+
+		rings := [][]mgl64.Vec2{ring}
+		if count >= 4 {
+			// The origin is inside, so is a hole around it.
+			hole := make([]mgl64.Vec2, random.Intn(4)+3)
+			offset := random.Float64()
+			for i := range hole {
+				angle := 2.0 * math.Pi * (float64(i) + offset) / float64(len(hole))
+				hole[i] = mgl64.Vec2{0.1 * math.Cos(angle), 0.1 * math.Sin(angle)}
+			}
+			rings = append(rings, hole)
+		}
+
+		convexes, err := decomposeConvex(rings)
+		if err != nil {
+			t.Fatal("Seed: ", seed, " ", err)
+		}
+		checkDecomposition(t, rings, convexes)
+	}
+}
+
+func TestDecomposeConvex_Error(t *testing.T) {
+	for _, rings := range [][][]mgl64.Vec2{
+		{{{0, 0}, {1, 1}}},
+		{{{0, 0}, {1, 1}, {2, 2}}},
+		// Bowtie
+		{{{0, 0}, {2, 2}, {2, 0}, {0, 2}}},
+		nil,
+		{{{0, 0}, {1, 0}, {math.NaN(), 1}}},
+	} {
+		_, err := decomposeConvex(rings)
+		if err == nil {
+			t.Error("Accepted: ", rings)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package closestio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ReadSTL reads the objects of an ASCII or binary STL file.
+// Each solid of an ASCII file is an [Object], and a binary file is an object without a name.
+// The same coordinates are merged into a vertex.
+func ReadSTL(reader io.Reader, options ReadOptions) ([]Object, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Binary files may also start with "solid", so the size is checked first.
+	if len(data) >= 84 {
+		count := binary.LittleEndian.Uint32(data[80:84])
+		if uint64(len(data)) == 84+50*uint64(count) {
+			return readBinarySTL(data[84:], int(count), options), nil
+		}
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("solid")) {
+		return readASCIISTL(bytes.NewReader(data), options)
+	}
+	return nil, errors.New("neither an ASCII nor a binary STL")
+}
+
+// stlBuilder merges the same coordinates of the triangles into a vertex.
+type stlBuilder struct {
+	builder  *objectBuilder
+	vertices []mgl64.Vec3
+	indices  map[mgl64.Vec3]int
+}
+
+func newSTLBuilder(name string) *stlBuilder {
+	return &stlBuilder{
+		builder: newObjectBuilder(name),
+		indices: map[mgl64.Vec3]int{},
+	}
+}
+
+func (builder *stlBuilder) addPolygon(polygon []mgl64.Vec3) {
+	indices := make([]int, len(polygon))
+	for i, vertex := range polygon {
+		index, ok := builder.indices[vertex]
+		if !ok {
+			index = len(builder.vertices)
+			builder.vertices = append(builder.vertices, vertex)
+			builder.indices[vertex] = index
+		}
+		indices[i] = index
+	}
+	builder.builder.addPolygon(builder.vertices, indices)
+}
+
+func readBinarySTL(data []byte, count int, options ReadOptions) []Object {
+	builder := newSTLBuilder("")
+	for i := 0; i < count; i += 1 {
+		// The normal, the three vertices and the attribute byte count
+		record := data[50*i+12 : 50*i+48]
+		triangle := make([]mgl64.Vec3, 3)
+		for j := range triangle {
+			for k := 0; k < 3; k += 1 {
+				triangle[j][k] = float64(math.Float32frombits(binary.LittleEndian.Uint32(record[12*j+4*k:])))
+			}
+			triangle[j] = options.convert(triangle[j])
+		}
+		builder.addPolygon(triangle)
+	}
+
+	return []Object{builder.builder.object}
+}
+
+func readASCIISTL(reader io.Reader, options ReadOptions) (objects []Object, err error) {
+	var builder *stlBuilder
+	polygon := []mgl64.Vec3{}
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line += 1 {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "solid":
+			builder = newSTLBuilder(strings.Join(fields[1:], " "))
+		case "vertex":
+			if builder == nil {
+				return nil, fmt.Errorf("line %d: vertex out of solid", line)
+			}
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("line %d: 3 coordinates are required", line)
+			}
+			vertex := mgl64.Vec3{}
+			for i := 0; i < 3; i += 1 {
+				vertex[i], err = strconv.ParseFloat(fields[i+1], 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+			}
+			polygon = append(polygon, options.convert(vertex))
+		case "endloop":
+			if len(polygon) < 3 {
+				return nil, fmt.Errorf("line %d: %d vertices in a loop", line, len(polygon))
+			}
+			builder.addPolygon(polygon)
+			polygon = polygon[:0]
+		case "endsolid":
+			if builder == nil {
+				return nil, fmt.Errorf("line %d: endsolid out of solid", line)
+			}
+			objects = append(objects, builder.builder.object)
+			builder = nil
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+	if builder != nil {
+		return nil, errors.New("solid not ended")
+	}
+
+	return
+}
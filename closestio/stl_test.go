@@ -0,0 +1,95 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// testTetrahedron is a tetrahedron with the outward triangles.
+var testTetrahedron = [][3]mgl64.Vec3{
+	{{0, 0, 0}, {0, 1, 0}, {1, 0, 0}},
+	{{0, 0, 0}, {1, 0, 0}, {0, 0, 1}},
+	{{0, 0, 0}, {0, 0, 1}, {0, 1, 0}},
+	{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+}
+
+func newTestBinarySTL(header string) []byte {
+	data := make([]byte, 80)
+	copy(data, header)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(testTetrahedron)))
+	for _, triangle := range testTetrahedron {
+		// The normal is not used.
+		data = append(data, make([]byte, 12)...)
+		for _, vertex := range triangle {
+			for k := 0; k < 3; k += 1 {
+				data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(vertex[k])))
+			}
+		}
+		data = append(data, 0, 0)
+	}
+
+	return data
+}
+
+func newTestASCIISTL(names ...string) string {
+	builder := strings.Builder{}
+	for i, name := range names {
+		builder.WriteString("solid " + name + "\n")
+		for _, triangle := range testTetrahedron {
+			builder.WriteString("  facet normal 0 0 0\n    outer loop\n")
+			for _, vertex := range triangle {
+				// The solids are 2 apart in x.
+				fmt.Fprintf(&builder, "      vertex %v %v %v\n", vertex[0]+float64(2*i), vertex[1], vertex[2])
+			}
+			builder.WriteString("    endloop\n  endfacet\n")
+		}
+		builder.WriteString("endsolid " + name + "\n")
+	}
+
+	return builder.String()
+}
+
+func TestReadSTL(t *testing.T) {
+	for _, data := range [][]byte{
+		newTestBinarySTL(""),
+		// Binary files may start with "solid".
+		newTestBinarySTL("solid binary"),
+		[]byte(newTestASCIISTL("one")),
+	} {
+		objects, err := ReadSTL(bytes.NewReader(data), ReadOptions{Scale: 1000.0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(objects) != 1 || len(objects[0].Vertices) != 4 || len(objects[0].Triangles) != 4 {
+			t.Fatal(objects)
+		}
+		min, max := objects[0].Bounds()
+		if min != (mgl64.Vec3{}) || max != (mgl64.Vec3{1000.0, 1000.0, 1000.0}) {
+			t.Error(min, max)
+		}
+	}
+}
+
+func TestReadSTL_Solids(t *testing.T) {
+	objects, err := ReadSTL(strings.NewReader(newTestASCIISTL("a", "b")), ReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 || objects[0].Name != "a" || objects[1].Name != "b" {
+		t.Fatal(objects)
+	}
+	if min, _ := objects[1].Bounds(); min[0] != 2.0 {
+		t.Error(min)
+	}
+
+	_, err = ReadSTL(strings.NewReader("solid a\nvertex 0 0 0\n"), ReadOptions{})
+	if err == nil {
+		t.Error("An unended solid is accepted.")
+	}
+}
@@ -0,0 +1,303 @@
+package closestio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Plane is a plane onto which WriteSVG projects a scene.
+type Plane int
+
+const (
+	XY Plane = iota
+	XZ
+	YZ
+)
+
+var planeNames = [...]string{"XY", "XZ", "YZ"}
+
+// planeAxes are the horizontal and the vertical axes of the planes.
+var planeAxes = [...][2]int{{0, 1}, {0, 2}, {1, 2}}
+
+func (plane Plane) String() string {
+	if plane < 0 || int(plane) >= len(planeNames) {
+		return fmt.Sprint("Plane(", int(plane), ")")
+	}
+	return planeNames[plane]
+}
+
+func (plane Plane) project(vertex mgl64.Vec3) mgl64.Vec2 {
+	axes := planeAxes[plane]
+	return mgl64.Vec2{vertex[axes[0]], vertex[axes[1]]}
+}
+
+const (
+	svgPanelSize = 400.0
+	svgMargin    = 24.0
+)
+
+// svgPanel maps the projected points into a square panel of an SVG image.
+type svgPanel struct {
+	origin mgl64.Vec2 // The top left corner in the image
+	center mgl64.Vec2 // The center of the projected points
+	scale  float64
+}
+
+func newSVGPanel(column int, row int, points []mgl64.Vec2) (panel svgPanel) {
+	panel.origin = mgl64.Vec2{float64(column) * svgPanelSize, float64(row) * svgPanelSize}
+
+	min := mgl64.Vec2{math.Inf(1), math.Inf(1)}
+	max := mgl64.Vec2{math.Inf(-1), math.Inf(-1)}
+	for _, point := range points {
+		for i := 0; i < 2; i += 1 {
+			min[i] = math.Min(min[i], point[i])
+			max[i] = math.Max(max[i], point[i])
+		}
+	}
+	if len(points) == 0 {
+		min, max = mgl64.Vec2{}, mgl64.Vec2{}
+	}
+	panel.center = min.Add(max).Mul(0.5)
+
+	size := math.Max(max[0]-min[0], max[1]-min[1])
+	if size == 0.0 {
+		size = 1.0
+	}
+	panel.scale = (svgPanelSize - 2.0*svgMargin) / size
+
+	return
+}
+
+// toSVG returns the coordinates of point in the image, where the y axis is down.
+func (panel *svgPanel) toSVG(point mgl64.Vec2) mgl64.Vec2 {
+	return mgl64.Vec2{
+		panel.origin[0] + 0.5*svgPanelSize + panel.scale*(point[0]-panel.center[0]),
+		panel.origin[1] + 0.5*svgPanelSize - panel.scale*(point[1]-panel.center[1]),
+	}
+}
+
+func (panel *svgPanel) toSVGPoints(points []mgl64.Vec2) string {
+	svgPoints := make([]string, len(points))
+	for i, point := range points {
+		svgPoint := panel.toSVG(point)
+		svgPoints[i] = fmt.Sprintf("%.2f,%.2f", svgPoint[0], svgPoint[1])
+	}
+	return strings.Join(svgPoints, " ")
+}
+
+// getConvexPolygon returns the counterclockwise convex hull of points by the monotone chain.
+func getConvexPolygon(points []mgl64.Vec2) (polygon []mgl64.Vec2) {
+	sorteds := append([]mgl64.Vec2{}, points...)
+	sort.Slice(sorteds, func(i int, j int) bool {
+		if sorteds[i][0] != sorteds[j][0] {
+			return sorteds[i][0] < sorteds[j][0]
+		}
+		return sorteds[i][1] < sorteds[j][1]
+	})
+	if len(sorteds) < 2 {
+		return sorteds
+	}
+
+	for _, isUpper := range []bool{false, true} {
+		start := len(polygon)
+		for i := range sorteds {
+			point := sorteds[i]
+			if isUpper {
+				point = sorteds[len(sorteds)-1-i]
+			}
+			for len(polygon)-start >= 2 && cross2D(polygon[len(polygon)-2], polygon[len(polygon)-1], point) <= 0.0 {
+				polygon = polygon[:len(polygon)-1]
+			}
+			polygon = append(polygon, point)
+		}
+		// The last point is the first of the other chain.
+		polygon = polygon[:len(polygon)-1]
+	}
+
+	return
+}
+
+func toSVGColor(color [4]float32) string {
+	return fmt.Sprintf("#%02x%02x%02x", uint8(255*color[0]), uint8(255*color[1]), uint8(255*color[2]))
+}
+
+// getDifferences returns the vertices of the Minkowski difference, ConvexHulls[1] - ConvexHulls[0].
+func (scene *Scene) getDifferences() (differences []mgl64.Vec3) {
+	for _, a := range scene.ConvexHulls[0] {
+		for _, b := range scene.ConvexHulls[1] {
+			differences = append(differences, b.Sub(*a))
+		}
+	}
+	return
+}
+
+// getDifference returns the vertex of the Minkowski difference by the indices of ConvexHulls.
+func (scene *Scene) getDifference(indices [2]int) (difference mgl64.Vec3, ok bool) {
+	for i, index := range indices {
+		if index < 0 || index >= len(scene.ConvexHulls[i]) {
+			return
+		}
+	}
+	return scene.ConvexHulls[1][indices[1]].Sub(*scene.ConvexHulls[0][indices[0]]), true
+}
+
+// WriteSVG writes scene projected onto the planes into an SVG image with a row of each plane.
+// If there are no planes, all of them are used.
+// The left panel has the convex hulls, the closest points and the segment of Direction.
+// If scene has History or Polytope, the right panel has the Minkowski difference, ConvexHulls[1] - ConvexHulls[0],
+// with the origin, Direction, Polytope and the simplices of History from faint to solid.
+func (scene *Scene) WriteSVG(writer io.Writer, planes ...Plane) error {
+	if len(planes) == 0 {
+		planes = []Plane{XY, XZ, YZ}
+	}
+	for _, plane := range planes {
+		if plane < 0 || int(plane) >= len(planeNames) {
+			return fmt.Errorf("closestio: unknown plane: %v", plane)
+		}
+	}
+	columnCount := 1
+	if len(scene.History) != 0 || scene.Polytope != nil {
+		columnCount = 2
+	}
+
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(
+		&buffer,
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%v\" height=\"%v\" viewBox=\"0 0 %v %v\" font-family=\"sans-serif\" font-size=\"12\">\n",
+		float64(columnCount)*svgPanelSize,
+		float64(len(planes))*svgPanelSize,
+		float64(columnCount)*svgPanelSize,
+		float64(len(planes))*svgPanelSize,
+	)
+	fmt.Fprintln(&buffer, `<rect width="100%" height="100%" fill="white"/>`)
+
+	for row, plane := range planes {
+		scene.writeSVGPanel(&buffer, row, plane)
+		if columnCount == 2 {
+			scene.writeSVGDifferencePanel(&buffer, row, plane)
+		}
+	}
+	fmt.Fprintln(&buffer, "</svg>")
+
+	_, err := buffer.WriteTo(writer)
+	return err
+}
+
+// writeSVGPanel writes the convex hulls, the closest points and the segment of Direction.
+func (scene *Scene) writeSVGPanel(buffer *bytes.Buffer, row int, plane Plane) {
+	projecteds := [2][]mgl64.Vec2{}
+	points := []mgl64.Vec2{}
+	for i, convex := range scene.ConvexHulls {
+		for _, vertex := range convex {
+			projecteds[i] = append(projecteds[i], plane.project(*vertex))
+		}
+		points = append(points, projecteds[i]...)
+		points = append(points, plane.project(scene.Points[i]))
+	}
+	panel := newSVGPanel(0, row, points)
+
+	fmt.Fprintf(buffer, "<text x=\"%v\" y=\"%v\">%v</text>\n", panel.origin[0]+4.0, panel.origin[1]+14.0, plane)
+	for i, projected := range projecteds {
+		material := sceneMaterials[hull0Material+i]
+		fmt.Fprintf(
+			buffer,
+			"<polygon points=\"%s\" fill=\"%s\" fill-opacity=\"%v\" stroke=\"%s\"/>\n",
+			panel.toSVGPoints(getConvexPolygon(projected)),
+			toSVGColor(material.color),
+			material.color[3],
+			toSVGColor(material.color),
+		)
+	}
+
+	direction := [2]mgl64.Vec2{plane.project(scene.Points[0]), plane.project(scene.Points[0].Add(scene.Direction))}
+	fmt.Fprintf(
+		buffer,
+		"<polyline points=\"%s\" stroke=\"%s\" stroke-width=\"2\"/>\n",
+		panel.toSVGPoints(direction[:]),
+		toSVGColor(sceneMaterials[directionMaterial].color),
+	)
+	for _, point := range scene.Points {
+		svgPoint := panel.toSVG(plane.project(point))
+		fmt.Fprintf(
+			buffer,
+			"<circle cx=\"%.2f\" cy=\"%.2f\" r=\"3\" fill=\"%s\"/>\n",
+			svgPoint[0],
+			svgPoint[1],
+			toSVGColor(sceneMaterials[pointMaterial].color),
+		)
+	}
+}
+
+// writeSVGDifferencePanel writes the Minkowski difference with the origin, Direction, Polytope and History.
+func (scene *Scene) writeSVGDifferencePanel(buffer *bytes.Buffer, row int, plane Plane) {
+	differences := []mgl64.Vec2{}
+	for _, difference := range scene.getDifferences() {
+		differences = append(differences, plane.project(difference))
+	}
+	polytope := []mgl64.Vec2{}
+	if scene.Polytope != nil {
+		for _, vertex := range scene.Polytope.Vertices {
+			polytope = append(polytope, plane.project(*vertex))
+		}
+	}
+	panel := newSVGPanel(1, row, append(append([]mgl64.Vec2{{}}, differences...), polytope...))
+
+	fmt.Fprintf(buffer, "<text x=\"%v\" y=\"%v\">%v of hull1 - hull0</text>\n", panel.origin[0]+4.0, panel.origin[1]+14.0, plane)
+	fmt.Fprintf(buffer, "<polygon points=\"%s\" fill=\"none\" stroke=\"gray\"/>\n", panel.toSVGPoints(getConvexPolygon(differences)))
+	if len(polytope) != 0 {
+		material := sceneMaterials[polytopeMaterial]
+		for _, triangle := range scene.Polytope.Triangles {
+			fmt.Fprintf(
+				buffer,
+				"<polygon points=\"%s\" fill=\"%s\" fill-opacity=\"0.1\" stroke=\"%s\"/>\n",
+				panel.toSVGPoints([]mgl64.Vec2{polytope[triangle[0]], polytope[triangle[1]], polytope[triangle[2]]}),
+				toSVGColor(material.color),
+				toSVGColor(material.color),
+			)
+		}
+	}
+
+	for i, simplex := range scene.History {
+		vertices := []mgl64.Vec2{}
+		for _, indices := range simplex {
+			difference, ok := scene.getDifference(indices)
+			if ok {
+				vertices = append(vertices, plane.project(difference))
+			}
+		}
+		// The edges between all the vertices draw a tetrahedron as well.
+		opacity := float64(i+1) / float64(len(scene.History))
+		fmt.Fprintf(buffer, "<g stroke=\"black\" stroke-opacity=\"%.3f\">\n", opacity)
+		for j := range vertices {
+			for k := j + 1; k < len(vertices); k += 1 {
+				fmt.Fprintf(buffer, "<polyline points=\"%s\"/>\n", panel.toSVGPoints([]mgl64.Vec2{vertices[j], vertices[k]}))
+			}
+			svgPoint := panel.toSVG(vertices[j])
+			fmt.Fprintf(buffer, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"2\" fill-opacity=\"%.3f\"/>\n", svgPoint[0], svgPoint[1], opacity)
+		}
+		fmt.Fprintln(buffer, "</g>")
+	}
+
+	direction := []mgl64.Vec2{{}, plane.project(scene.Direction)}
+	fmt.Fprintf(
+		buffer,
+		"<polyline points=\"%s\" stroke=\"%s\" stroke-width=\"2\"/>\n",
+		panel.toSVGPoints(direction),
+		toSVGColor(sceneMaterials[directionMaterial].color),
+	)
+	origin := panel.toSVG(mgl64.Vec2{})
+	fmt.Fprintf(
+		buffer,
+		"<path d=\"M %.2f %.2f h 8 M %.2f %.2f v 8\" stroke=\"black\"/>\n",
+		origin[0]-4.0,
+		origin[1],
+		origin[0],
+		origin[1]-4.0,
+	)
+}
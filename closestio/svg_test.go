@@ -0,0 +1,80 @@
+package closestio
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestGetConvexPolygon(t *testing.T) {
+	polygon := getConvexPolygon([]mgl64.Vec2{
+		{1.0, 1.0},
+		{0.0, 0.0},
+		{0.5, 0.5}, // Inside
+		{1.0, 0.0},
+		{0.5, 0.0}, // On the edge
+		{0.0, 1.0},
+		{1.0, 1.0}, // Duplicated
+	})
+	if fmt.Sprint(polygon) != "[[0 0] [1 0] [1 1] [0 1]]" {
+		t.Error(polygon)
+	}
+
+	polygon = getConvexPolygon([]mgl64.Vec2{{1.0, 2.0}})
+	if len(polygon) != 1 {
+		t.Error(polygon)
+	}
+}
+
+func TestScene_WriteSVG(t *testing.T) {
+	scene := newTestScene()
+	if len(scene.History) == 0 {
+		t.Fatal("History is empty.")
+	}
+
+	buffer := bytes.Buffer{}
+	err := scene.WriteSVG(&buffer, XY, YZ)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Counts the elements
+	counts := map[string]int{}
+	decoder := xml.NewDecoder(&buffer)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			counts[element.Name.Local] += 1
+		}
+	}
+	if counts["svg"] != 1 || counts["text"] != 4 || counts["g"] != 2*len(scene.History) {
+		t.Error(counts)
+	}
+	// The convex hulls, the Minkowski difference and the faces of the polytope in each plane
+	if counts["polygon"] != 2*(3+len(scene.Polytope.Triangles)) {
+		t.Error(counts)
+	}
+
+	scene.History = nil
+	scene.Polytope = nil
+	buffer.Reset()
+	err = scene.WriteSVG(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buffer.String(), `width="400" height="1200"`) || strings.Contains(buffer.String(), "hull1 - hull0") {
+		t.Error("The right panel is written without History and Polytope.")
+	}
+
+	err = scene.WriteSVG(&buffer, Plane(3))
+	if err == nil || Plane(3).String() != "Plane(3)" {
+		t.Error("An unknown plane is accepted.")
+	}
+}
@@ -0,0 +1,162 @@
+package closestio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ParseWKT parses a POLYGON or MULTIPOLYGON of the well-known text into the extrusions between
+// minAltitude and maxAltitude, one for each polygon. The z and m coordinates are ignored.
+func ParseWKT(text string, minAltitude float64, maxAltitude float64) (extrusions []Extrusion, err error) {
+	parser := wktParser{
+		text: text,
+	}
+	polygons, err := parser.parse()
+	if err != nil {
+		return nil, fmt.Errorf("closestio: WKT at %d: %w", parser.position, err)
+	}
+
+	for _, rings := range polygons {
+		extrusions = append(extrusions, Extrusion{
+			Rings:       rings,
+			MinAltitude: minAltitude,
+			MaxAltitude: maxAltitude,
+		})
+	}
+	return
+}
+
+// wktParser is a recursive descent parser of the well-known text.
+type wktParser struct {
+	text     string
+	position int
+}
+
+func (parser *wktParser) parse() (polygons [][][]mgl64.Vec2, err error) {
+	keyword := strings.ToUpper(parser.readWord())
+	dimension := strings.ToUpper(parser.peekWord())
+	if dimension == "Z" || dimension == "M" || dimension == "ZM" {
+		parser.readWord()
+	}
+	if strings.ToUpper(parser.peekWord()) == "EMPTY" {
+		parser.readWord()
+		return nil, parser.expectEnd()
+	}
+
+	switch keyword {
+	case "POLYGON":
+		rings, err := parser.readPolygon()
+		if err != nil {
+			return nil, err
+		}
+		polygons = append(polygons, rings)
+	case "MULTIPOLYGON":
+		err = parser.readList(func() error {
+			rings, err := parser.readPolygon()
+			polygons = append(polygons, rings)
+			return err
+		})
+		if err != nil {
+			return
+		}
+	default:
+		return nil, fmt.Errorf("%q is neither POLYGON nor MULTIPOLYGON", keyword)
+	}
+
+	return polygons, parser.expectEnd()
+}
+
+func (parser *wktParser) readPolygon() (rings [][]mgl64.Vec2, err error) {
+	err = parser.readList(func() error {
+		ring := []mgl64.Vec2{}
+		err := parser.readList(func() error {
+			point := mgl64.Vec2{}
+			count := 0
+			for ; parser.peekWord() != ""; count += 1 {
+				value, err := strconv.ParseFloat(parser.readWord(), 64)
+				if err != nil {
+					return err
+				}
+				if math.IsNaN(value) || math.IsInf(value, 0) {
+					return errors.New("a coordinate is not finite")
+				}
+				if count < 2 {
+					point[count] = value
+				}
+			}
+			if count < 2 || count > 4 {
+				return fmt.Errorf("a position has %d coordinates, but 2 to 4 are required", count)
+			}
+			ring = append(ring, point)
+			return nil
+		})
+		rings = append(rings, ring)
+		return err
+	})
+
+	return
+}
+
+// readList reads "(" item { "," item } ")" by readItem.
+func (parser *wktParser) readList(readItem func() error) error {
+	if !parser.readSymbol('(') {
+		return errors.New("( is expected")
+	}
+	for {
+		err := readItem()
+		if err != nil {
+			return err
+		}
+		if parser.readSymbol(')') {
+			return nil
+		}
+		if !parser.readSymbol(',') {
+			return errors.New(", or ) is expected")
+		}
+	}
+}
+
+func (parser *wktParser) skipSpaces() {
+	for parser.position < len(parser.text) && unicode.IsSpace(rune(parser.text[parser.position])) {
+		parser.position += 1
+	}
+}
+
+func (parser *wktParser) readSymbol(symbol byte) bool {
+	parser.skipSpaces()
+	if parser.position < len(parser.text) && parser.text[parser.position] == symbol {
+		parser.position += 1
+		return true
+	}
+	return false
+}
+
+// peekWord returns the next word, which is a keyword or a number, without reading it.
+func (parser *wktParser) peekWord() string {
+	parser.skipSpaces()
+	end := parser.position
+	for end < len(parser.text) && !unicode.IsSpace(rune(parser.text[end])) && !strings.ContainsRune("(),", rune(parser.text[end])) {
+		end += 1
+	}
+	return parser.text[parser.position:end]
+}
+
+func (parser *wktParser) readWord() string {
+	word := parser.peekWord()
+	parser.position += len(word)
+	return word
+}
+
+func (parser *wktParser) expectEnd() error {
+	parser.skipSpaces()
+	if parser.position != len(parser.text) {
+		return errors.New("the end is expected")
+	}
+	return nil
+}
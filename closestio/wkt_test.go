@@ -0,0 +1,66 @@
+package closestio
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestParseWKT(t *testing.T) {
+	for _, testCase := range []struct {
+		text   string
+		counts [][]int // The numbers of the points of the rings of each polygon
+	}{
+		{"POLYGON ((0 0, 1 0, 1 1, 0 0))", [][]int{{4}}},
+		{"polygon z((0 0 5, 1 0 5, 1 1 5, 0 0 5), (0.2 0.1 5, 0.8 0.1 5, 0.8 0.7 5, 0.2 0.1 5))", [][]int{{4, 4}}},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5),(5.1 5.1,5.2 5.1,5.2 5.2,5.1 5.1)))", [][]int{{4}, {4, 4}}},
+		{"POLYGON EMPTY", nil},
+	} {
+		extrusions, err := ParseWKT(testCase.text, 10.0, 20.0)
+		if err != nil {
+			t.Fatal(testCase.text, ": ", err)
+		}
+		if len(extrusions) != len(testCase.counts) {
+			t.Fatal(testCase.text, ": ", extrusions)
+		}
+		for i, extrusion := range extrusions {
+			if len(extrusion.Rings) != len(testCase.counts[i]) || extrusion.MinAltitude != 10.0 || extrusion.MaxAltitude != 20.0 {
+				t.Error(testCase.text, ": ", extrusion)
+				continue
+			}
+			for j, ring := range extrusion.Rings {
+				if len(ring) != testCase.counts[i][j] {
+					t.Error(testCase.text, ": ", ring)
+				}
+			}
+		}
+	}
+
+	extrusions, err := ParseWKT("POLYGON((139.5 35.25, 139.75 35.25, 139.75 35.5))", 0.0, 0.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extrusions[0].Rings[0][1] != (mgl64.Vec2{139.75, 35.25}) {
+		t.Error(extrusions[0].Rings)
+	}
+}
+
+func TestParseWKT_Error(t *testing.T) {
+	for _, text := range []string{
+		"POINT (0 0)",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0)",
+		"POLYGON ((0 0, 1 a, 1 1, 0 0))",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0)) POLYGON",
+		"POLYGON (0 0, 1 0, 1 1, 0 0)",
+		"POLYGON ((0 0, 1, 1 1, 0 0))",
+		"POLYGON ((0 0, 1 0 0 0 0, 1 1, 0 0))",
+		"POLYGON (())",
+		"POLYGON ((0 0, NaN 0, 1 1, 0 0))",
+		"POLYGON ((0 0, 1 Inf, 1 1, 0 0))",
+	} {
+		_, err := ParseWKT(text, 0.0, 1.0)
+		if err == nil {
+			t.Error("Accepted: ", text)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Command closest-server serves the measurements of convex hulls over HTTP and JSON.
+// See the package closesthttp for the endpoints.
+//
+// Usage:
+//
+//	closest-server [flags]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/trajectoryjp/closest_go/closesthttp"
+)
+
+func main() {
+	address := flag.String("address", "localhost:8080", "The address to listen on.")
+	timeout := flag.Duration("timeout", closesthttp.DefaultTimeout, "The time limit to measure for each request.")
+	maxVertexCount := flag.Int("max-vertices", closesthttp.DefaultMaxVertexCount, "The maximum number of the vertices of each convex hull.")
+	maxPairCount := flag.Int("max-pairs", closesthttp.DefaultMaxPairCount, "The maximum number of the pairs of a batch.")
+	maxBodySize := flag.Int64("max-body", closesthttp.DefaultMaxBodySize, "The maximum size of the body of each request in bytes.")
+	maxConcurrency := flag.Int("max-concurrency", 0, "The maximum number of the requests measuring at the same time. If this is zero, GOMAXPROCS is used.")
+	flag.Parse()
+
+	server := &http.Server{
+		Addr: *address,
+		Handler: &closesthttp.Handler{
+			Timeout:        *timeout,
+			MaxVertexCount: *maxVertexCount,
+			MaxPairCount:   *maxPairCount,
+			MaxBodySize:    *maxBodySize,
+			MaxConcurrency: *maxConcurrency,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Print("Listening on ", *address)
+	err := server.ListenAndServe()
+	if !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+// scenario is the named convex hulls and the pairs of them to measure.
+type scenario struct {
+	convexHulls map[string][]*mgl64.Vec3
+	pairs       [][2]string
+}
+
+// batchOptions are the options of the batch subcommand for each pair.
+type batchOptions struct {
+	isNonnegative bool
+	isGeodetic    bool
+	tolerance     float64
+}
+
+// batchResult is the result of a pair.
+type batchResult struct {
+	pair           [2]string
+	distance       float64
+	iterationCount int
+	termination    closest.Termination
+	duration       time.Duration
+}
+
+func runBatch(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("closest batch", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: closest batch [flags] scenario")
+		flags.PrintDefaults()
+	}
+	format := flags.String("format", "", "The format of the scenario: jsonl or csv. If empty, the extension is used.")
+	isNonnegative := flags.Bool("nonnegative", false, "Measure the nonnegative distance instead of the depth.")
+	isGeodetic := flags.Bool("geodetic", false, "The coordinates are the longitudes and the latitudes in degrees, and the heights in meters.")
+	tolerance := flags.Float64("tolerance", 0.0, "The tolerance relative to the magnitude of the coordinates. If zero, the default is used.")
+	parallelism := flags.Int("parallel", runtime.GOMAXPROCS(0), "The number of the pairs measured in parallel.")
+	output := flags.String("output", "text", "The output format: text or csv.")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("a scenario file is required")
+	}
+	if *parallelism < 1 {
+		return errors.New("-parallel must be positive")
+	}
+
+	theScenario, err := readScenarioFile(flags.Arg(0), *format)
+	if err != nil {
+		return err
+	}
+
+	results := measurePairs(theScenario, batchOptions{
+		isNonnegative: *isNonnegative,
+		isGeodetic:    *isGeodetic,
+		tolerance:     *tolerance,
+	}, *parallelism)
+
+	switch *output {
+	case "text":
+		return writeBatchText(stdout, results)
+	case "csv":
+		return writeBatchCSV(stdout, results)
+	default:
+		return fmt.Errorf("unknown output format: %s", *output)
+	}
+}
+
+// measurePairs measures the pairs of theScenario by parallelism goroutines.
+// The results are in the order of the pairs.
+func measurePairs(theScenario *scenario, options batchOptions, parallelism int) []batchResult {
+	results := make([]batchResult, len(theScenario.pairs))
+	indices := make(chan int)
+	group := sync.WaitGroup{}
+	for i := 0; i < parallelism; i += 1 {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for index := range indices {
+				results[index] = measurePair(theScenario, theScenario.pairs[index], options)
+			}
+		}()
+	}
+
+	for i := range theScenario.pairs {
+		indices <- i
+	}
+	close(indices)
+	group.Wait()
+
+	return results
+}
+
+func measurePair(theScenario *scenario, pair [2]string, options batchOptions) batchResult {
+	measure := closest.Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			theScenario.convexHulls[pair[0]],
+			theScenario.convexHulls[pair[1]],
+		},
+		Tolerance: options.tolerance,
+	}
+	if options.isGeodetic {
+		enu := closest.NewENU(measure.ConvexHulls[:]...)
+		for i, convex := range measure.ConvexHulls {
+			measure.ConvexHulls[i] = enu.FromGeodetics(convex)
+		}
+	}
+
+	start := time.Now()
+	if options.isNonnegative {
+		measure.MeasureNonnegativeDistance()
+	} else {
+		measure.MeasureDistance()
+	}
+	duration := time.Since(start)
+
+	return batchResult{
+		pair:           pair,
+		distance:       measure.Distance,
+		iterationCount: measure.IterationCount,
+		termination:    measure.Termination,
+		duration:       duration,
+	}
+}
+
+func writeBatchText(writer io.Writer, results []batchResult) error {
+	tabWriter := tabwriter.NewWriter(writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tabWriter, "Hull0\tHull1\tDistance\tIterations\tTermination\tTime")
+	for _, theResult := range results {
+		fmt.Fprintf(
+			tabWriter,
+			"%s\t%s\t%v\t%d\t%v\t%v\n",
+			theResult.pair[0],
+			theResult.pair[1],
+			theResult.distance,
+			theResult.iterationCount,
+			theResult.termination,
+			theResult.duration,
+		)
+	}
+
+	return tabWriter.Flush()
+}
+
+func writeBatchCSV(writer io.Writer, results []batchResult) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"hull0", "hull1", "distance", "iterations", "termination", "nanoseconds"})
+	for _, theResult := range results {
+		csvWriter.Write([]string{
+			theResult.pair[0],
+			theResult.pair[1],
+			strconv.FormatFloat(theResult.distance, 'g', -1, 64),
+			strconv.Itoa(theResult.iterationCount),
+			theResult.termination.String(),
+			strconv.FormatInt(theResult.duration.Nanoseconds(), 10),
+		})
+	}
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// readScenarioFile reads a scenario from the file in format.
+// If format is empty, the extension of the file is used.
+func readScenarioFile(path string, format string) (*scenario, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	theScenario, err := readScenario(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return theScenario, nil
+}
+
+func readScenario(reader io.Reader, format string) (theScenario *scenario, err error) {
+	theScenario = &scenario{
+		convexHulls: map[string][]*mgl64.Vec3{},
+	}
+
+	switch format {
+	case "jsonl":
+		err = theScenario.readJSONL(reader)
+	case "csv":
+		err = theScenario.readCSV(reader)
+	default:
+		err = fmt.Errorf("unknown format: %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, pair := range theScenario.pairs {
+		for _, name := range pair {
+			if len(theScenario.convexHulls[name]) == 0 {
+				return nil, fmt.Errorf("pair %d: unknown hull %q", i, name)
+			}
+		}
+	}
+	return
+}
+
+// readJSONL reads the lines {"hull": name, "vertices": [[x, y, z], ...]} and {"pair": [name0, name1]}.
+func (scenario *scenario) readJSONL(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 1<<26)
+	for line := 1; scanner.Scan(); line += 1 {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		record := struct {
+			Hull     string       `json:"hull"`
+			Vertices [][3]float64 `json:"vertices"`
+			Pair     []string     `json:"pair"`
+		}{}
+		err := json.Unmarshal([]byte(text), &record)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch {
+		case record.Hull != "" && record.Pair == nil:
+			if _, ok := scenario.convexHulls[record.Hull]; ok {
+				return fmt.Errorf("line %d: duplicate hull %q", line, record.Hull)
+			}
+			convex := make([]*mgl64.Vec3, len(record.Vertices))
+			for i, vertex := range record.Vertices {
+				vector := mgl64.Vec3(vertex)
+				convex[i] = &vector
+			}
+			scenario.convexHulls[record.Hull] = convex
+		case record.Hull == "" && len(record.Pair) == 2:
+			scenario.pairs = append(scenario.pairs, [2]string{record.Pair[0], record.Pair[1]})
+		default:
+			return fmt.Errorf("line %d: neither a hull nor a pair", line)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// readCSV reads the rows hull,name,x,y,z for each vertex, and pair,name0,name1.
+// The rows of a hull must be consecutive. The first row is skipped if it is neither.
+func (scenario *scenario) readCSV(reader io.Reader) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	lastHull := ""
+	for line := 1; ; line += 1 {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case record[0] == "hull" && len(record) == 5:
+			vertex, err := parseVec3(record[2:])
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			if _, ok := scenario.convexHulls[record[1]]; ok && record[1] != lastHull {
+				return fmt.Errorf("line %d: duplicate hull %q", line, record[1])
+			}
+			scenario.convexHulls[record[1]] = append(scenario.convexHulls[record[1]], &vertex)
+			lastHull = record[1]
+		case record[0] == "pair" && len(record) == 3:
+			lastHull = ""
+			scenario.pairs = append(scenario.pairs, [2]string{record[1], record[2]})
+		case line == 1:
+			// Header
+		default:
+			return fmt.Errorf("line %d: neither a hull nor a pair", line)
+		}
+	}
+}
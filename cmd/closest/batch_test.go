@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	closest "github.com/trajectoryjp/closest_go"
+)
+
+const testScenarioJSONL = `{"hull": "a", "vertices": [[0, 0, 0], [1, 0, 0], [0, 1, 0], [0, 0, 1]]}
+{"hull": "b", "vertices": [[3, 0, 0], [4, 0, 0], [3, 1, 0], [3, 0, 1]]}
+{"hull": "c", "vertices": [[0.5, 0, 0], [1.5, 0, 0], [0.5, 1, 0], [0.5, 0, 1]]}
+
+{"pair": ["a", "b"]}
+{"pair": ["a", "c"]}
+{"pair": ["b", "c"]}
+`
+
+func TestReadScenario(t *testing.T) {
+	csvScenario := "kind,name,x,y,z\n"
+	for _, name := range []string{"a", "b"} {
+		for _, vertex := range []string{"0,0,0", "1,0,0", "0,1,0"} {
+			csvScenario += "hull," + name + "," + vertex + "\n"
+		}
+	}
+	csvScenario += "pair,a,b\n"
+
+	for format, content := range map[string]string{
+		"jsonl": testScenarioJSONL,
+		"csv":   csvScenario,
+	} {
+		theScenario, err := readScenario(strings.NewReader(content), format)
+		if err != nil {
+			t.Fatal(format, ": ", err)
+		}
+		if len(theScenario.pairs) == 0 || theScenario.pairs[0] != [2]string{"a", "b"} || len(theScenario.convexHulls["b"]) < 3 {
+			t.Error(format, ": ", theScenario)
+		}
+	}
+
+	for format, content := range map[string]string{
+		"jsonl": `{"pair": ["a", "b"]}`,
+		"csv":   "hull,a,0,0,0\npair,a,b\n",
+	} {
+		_, err := readScenario(strings.NewReader(content), format)
+		if err == nil {
+			t.Error(format, ": An unknown hull is accepted.")
+		}
+	}
+	for format, content := range map[string]string{
+		"jsonl": `{"hull": "a"}` + "\n" + `{"hull": "a"}`,
+		"csv":   "hull,a,0,0,0\nhull,b,1,0,0\nhull,a,2,0,0\n",
+	} {
+		_, err := readScenario(strings.NewReader(content), format)
+		if err == nil {
+			t.Error(format, ": A duplicate hull is accepted.")
+		}
+	}
+}
+
+func TestMeasurePairs(t *testing.T) {
+	theScenario, err := readScenario(strings.NewReader(testScenarioJSONL), "jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, parallelism := range []int{1, 3, 8} {
+		results := measurePairs(theScenario, batchOptions{}, parallelism)
+		if len(results) != 3 {
+			t.Fatal(results)
+		}
+		for i, termination := range []closest.Termination{closest.Converged, closest.Expanded, closest.Converged} {
+			if results[i].pair != theScenario.pairs[i] || results[i].termination != termination {
+				t.Error(parallelism, ": ", results[i])
+			}
+		}
+		if results[0].distance != 2.0 || results[1].distance >= 0.0 {
+			t.Error(parallelism, ": ", results)
+		}
+	}
+}
+
+func TestRunBatch(t *testing.T) {
+	path := writeFile(t, "scenario.jsonl", testScenarioJSONL)
+
+	stdout := bytes.Buffer{}
+	err := run([]string{"batch", "-output", "csv", "-nonnegative", path}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || records[2][0] != "a" || records[2][1] != "c" || records[2][2] != "0" || records[2][4] != "Touched" {
+		t.Error(records)
+	}
+
+	stdout.Reset()
+	err = run([]string{"batch", path}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[0], "Hull0") {
+		t.Error(stdout.String())
+	}
+}
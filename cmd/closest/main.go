@@ -0,0 +1,198 @@
+// Command closest measures the distance or the depth between two convex hulls in files.
+//
+// Usage:
+//
+//	closest [flags] hull0 hull1
+//	closest batch [flags] scenario
+//
+// The convex hulls are read by the extensions of the files, or by -format.
+// JSON files are arrays of [x, y, z], CSV files are rows of x, y and z with an optional header,
+// and OBJ, STL and PLY files are the vertices of all the objects.
+// With -geodetic, Points are geodetic, but Direction is in meters in the ENU frame at enuOrigin.
+// -scene writes the convex hulls, the closest points and the direction into a glTF, OBJ or SVG file to inspect.
+//
+// The batch subcommand measures the pairs of the named convex hulls in a scenario file in parallel,
+// and writes a table of the results with the timings and the terminations.
+// JSON Lines scenarios have the lines {"hull": name, "vertices": [[x, y, z], ...]} and {"pair": [name0, name1]}.
+// CSV scenarios have the rows hull,name,x,y,z for each vertex, and pair,name0,name1.
+// The rows of a hull are consecutive, and the names of the hulls are unique in both formats.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+	closest "github.com/trajectoryjp/closest_go"
+	"github.com/trajectoryjp/closest_go/closestio"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "closest:", err)
+		os.Exit(1)
+	}
+}
+
+// result is the output of a measurement.
+type result struct {
+	Distance    float64       `json:"distance"`
+	Direction   [3]float64    `json:"direction"`
+	Points      [2][3]float64 `json:"points"`
+	Ons         [2][]int      `json:"ons"`
+	Termination string        `json:"termination"`
+	// ENUOrigin is the origin of the ENU frame of Direction in meters if the coordinates are geodetic.
+	ENUOrigin *[3]float64 `json:"enuOrigin,omitempty"`
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) != 0 && args[0] == "batch" {
+		return runBatch(args[1:], stdout, stderr)
+	}
+
+	flags := flag.NewFlagSet("closest", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: closest [flags] hull0 hull1")
+		flags.PrintDefaults()
+	}
+	format := flags.String("format", "", "The format of the files: json, csv, obj, stl or ply. If empty, the extensions are used.")
+	isNonnegative := flags.Bool("nonnegative", false, "Measure the nonnegative distance instead of the depth.")
+	output := flags.String("output", "text", "The output format: text or json.")
+	isGeodetic := flags.Bool("geodetic", false, "The coordinates are the longitudes and the latitudes in degrees, and the heights in meters. Direction is in meters in the ENU frame at enuOrigin.")
+	tolerance := flags.Float64("tolerance", 0.0, "The tolerance relative to the magnitude of the coordinates. If zero, the default is used.")
+	direction := flags.String("direction", "", "The initial direction from hull0 to hull1 as x,y,z.")
+	scenePath := flags.String("scene", "", "Write the scene of the measurement into the file by its extension: .obj, .gltf, .glb or .svg. The geodetic coordinates are in the ENU frame.")
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return errors.New("two files are required")
+	}
+
+	measure := closest.Measure{
+		Tolerance:     *tolerance,
+		KeepsPolytope: *scenePath != "",
+		KeepsHistory:  *scenePath != "",
+	}
+	for i := 0; i < 2; i += 1 {
+		measure.ConvexHulls[i], err = readHullFile(flags.Arg(i), *format)
+		if err != nil {
+			return err
+		}
+		if len(measure.ConvexHulls[i]) == 0 {
+			return fmt.Errorf("%s has no vertices", flags.Arg(i))
+		}
+	}
+	if *direction != "" {
+		measure.Direction, err = parseVec3(strings.Split(*direction, ","))
+		if err != nil {
+			return fmt.Errorf("-direction: %w", err)
+		}
+	}
+
+	var enu closest.ENU
+	if *isGeodetic {
+		enu = closest.NewENU(measure.ConvexHulls[:]...)
+		for i, convex := range measure.ConvexHulls {
+			measure.ConvexHulls[i] = enu.FromGeodetics(convex)
+		}
+	}
+
+	if *isNonnegative {
+		measure.MeasureNonnegativeDistance()
+	} else {
+		measure.MeasureDistance()
+	}
+
+	if *scenePath != "" {
+		err = closestio.NewScene(&measure).WriteFile(*scenePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *isGeodetic {
+		for i := range measure.Points {
+			measure.Points[i] = enu.ToGeodetic(measure.Points[i])
+		}
+	}
+
+	theResult := newResult(&measure)
+	if *isGeodetic {
+		origin := [3]float64(enu.Origin)
+		theResult.ENUOrigin = &origin
+	}
+	switch *output {
+	case "text":
+		return writeText(stdout, theResult)
+	case "json":
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(theResult)
+	default:
+		return fmt.Errorf("unknown output format: %s", *output)
+	}
+}
+
+func newResult(measure *closest.Measure) (theResult result) {
+	theResult.Distance = measure.Distance
+	theResult.Direction = measure.Direction
+	theResult.Termination = measure.Termination.String()
+	for i := range measure.Points {
+		theResult.Points[i] = measure.Points[i]
+		theResult.Ons[i] = []int{}
+		for index := range measure.Ons[i] {
+			theResult.Ons[i] = append(theResult.Ons[i], index)
+		}
+		sort.Ints(theResult.Ons[i])
+	}
+
+	return
+}
+
+func writeText(writer io.Writer, theResult result) error {
+	direction := fmt.Sprint("Direction: ", theResult.Direction)
+	if theResult.ENUOrigin != nil {
+		direction = fmt.Sprint("Direction (ENU at ", *theResult.ENUOrigin, "): ", theResult.Direction)
+	}
+	_, err := fmt.Fprintf(
+		writer,
+		"Distance: %v\n%s\nPoints: %v %v\nOns: %v %v\nTermination: %v\n",
+		theResult.Distance,
+		direction,
+		theResult.Points[0],
+		theResult.Points[1],
+		theResult.Ons[0],
+		theResult.Ons[1],
+		theResult.Termination,
+	)
+	return err
+}
+
+func parseVec3(fields []string) (vector mgl64.Vec3, err error) {
+	if len(fields) != 3 {
+		return vector, fmt.Errorf("3 coordinates are required, but %d are given", len(fields))
+	}
+	for i, field := range fields {
+		vector[i], err = strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
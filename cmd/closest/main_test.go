@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadHull(t *testing.T) {
+	contents := map[string]string{
+		"json": "[[0, 0, 0], [1, 0, 0], [0, 1, 0]]",
+		"csv":  "x,y,z\n0,0,0\n1,0,0\n0,1,0\n",
+		"obj":  "# Triangle\nv 0 0 0\nv 1 0 0 1.0\nvn 0 0 1\nv 0 1 0\nf 1 2 3\n",
+	}
+	for format, content := range contents {
+		convex, err := readHull(strings.NewReader(content), format)
+		if err != nil {
+			t.Fatal(format, ": ", err)
+		}
+		if len(convex) != 3 || convex[1][0] != 1.0 || convex[2][1] != 1.0 {
+			t.Error(format, ": ", convex)
+		}
+	}
+
+	_, err := readHull(strings.NewReader("0,0,0\n1,a,0\n"), "csv")
+	if err == nil {
+		t.Error("A row not numeric is accepted.")
+	}
+	_, err = readHull(strings.NewReader(""), "dae")
+	if err == nil {
+		t.Error("An unknown format is accepted.")
+	}
+}
+
+func TestRun(t *testing.T) {
+	paths := [2]string{
+		writeFile(t, "a.json", "[[0, 0, 0], [1, 0, 0], [0, 1, 0], [0, 0, 1]]"),
+		writeFile(t, "b.csv", "3,0,0\n4,0,0\n3,1,0\n3,0,1\n"),
+	}
+
+	stdout := bytes.Buffer{}
+	err := run([]string{"-output", "json", "-direction", "1,0,0", paths[0], paths[1]}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	theResult := result{}
+	err = json.Unmarshal(stdout.Bytes(), &theResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(theResult.Distance-2.0) > 1e-12 || theResult.Ons[0][0] != 1 || theResult.Ons[1][0] != 0 {
+		t.Error(stdout.String())
+	}
+
+	stdout.Reset()
+	err = run([]string{"-nonnegative", paths[0], paths[1]}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stdout.String(), "Distance: 2\n") {
+		t.Error(stdout.String())
+	}
+
+	scenePath := filepath.Join(t.TempDir(), "scene.glb")
+	err = run([]string{"-scene", scenePath, paths[0], paths[1]}, &bytes.Buffer{}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(scenePath)
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = run([]string{paths[0]}, &stdout, &bytes.Buffer{})
+	if err == nil {
+		t.Error("A missing file is accepted.")
+	}
+}
+
+func TestRun_Geodetic(t *testing.T) {
+	// Two boxes 100 m apart in height
+	paths := [2]string{
+		writeFile(t, "a.csv", "lon,lat,height\n139.0,35.0,0\n139.001,35.0,0\n139.0,35.001,0\n139.0,35.0,10\n"),
+		writeFile(t, "b.csv", "139.0,35.0,110\n139.001,35.0,110\n139.0,35.001,110\n139.0,35.0,120\n"),
+	}
+
+	stdout := bytes.Buffer{}
+	err := run([]string{"-geodetic", "-output", "json", paths[0], paths[1]}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	theResult := result{}
+	err = json.Unmarshal(stdout.Bytes(), &theResult)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(theResult.Distance-100.0) > 1e-3 {
+		t.Error(stdout.String())
+	}
+	if math.Abs(theResult.Points[1][2]-theResult.Points[0][2]-100.0) > 1e-3 || math.Abs(theResult.Points[0][0]-139.0) > 1e-3 {
+		t.Error(stdout.String())
+	}
+	// Direction is up in the ENU frame.
+	if theResult.ENUOrigin == nil || math.Abs(theResult.Direction[2]-100.0) > 1e-3 {
+		t.Error(stdout.String())
+	}
+
+	stdout.Reset()
+	err = run([]string{"-geodetic", paths[0], paths[1]}, &stdout, &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stdout.String(), "\nDirection (ENU at [") {
+		t.Error(stdout.String())
+	}
+}
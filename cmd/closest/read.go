@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/trajectoryjp/closest_go/closestio"
+)
+
+// readHullFile reads the vertices of a convex hull from the file in format.
+// If format is empty, the extension of the file is used.
+func readHullFile(path string, format string) ([]*mgl64.Vec3, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	convex, err := readHull(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return convex, nil
+}
+
+func readHull(reader io.Reader, format string) ([]*mgl64.Vec3, error) {
+	switch format {
+	case "json":
+		return readJSON(reader)
+	case "csv":
+		return readCSV(reader)
+	case "obj":
+		return readMesh(reader, closestio.ReadOBJ)
+	case "stl":
+		return readMesh(reader, closestio.ReadSTL)
+	case "ply":
+		return readMesh(reader, closestio.ReadPLY)
+	default:
+		return nil, fmt.Errorf("unknown format: %q", format)
+	}
+}
+
+// readJSON reads an array of [x, y, z].
+func readJSON(reader io.Reader) (convex []*mgl64.Vec3, err error) {
+	vertices := [][3]float64{}
+	err = json.NewDecoder(reader).Decode(&vertices)
+	if err != nil {
+		return
+	}
+
+	for _, vertex := range vertices {
+		vector := mgl64.Vec3(vertex)
+		convex = append(convex, &vector)
+	}
+	return
+}
+
+// readCSV reads rows of x, y and z. The first row is skipped if it is not numeric.
+func readCSV(reader io.Reader) (convex []*mgl64.Vec3, err error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 3
+	csvReader.TrimLeadingSpace = true
+
+	for line := 1; ; line += 1 {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return convex, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		vertex, err := parseVec3(record)
+		if err != nil {
+			if line == 1 {
+				// Header
+				continue
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		convex = append(convex, &vertex)
+	}
+}
+
+// readMesh reads the vertices of all the objects of a mesh file by read.
+func readMesh(reader io.Reader, read func(io.Reader, closestio.ReadOptions) ([]closestio.Object, error)) (convex []*mgl64.Vec3, err error) {
+	objects, err := read(reader, closestio.ReadOptions{})
+	if err != nil {
+		return
+	}
+
+	for _, object := range objects {
+		convex = append(convex, object.Vertices...)
+	}
+	return
+}
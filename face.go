@@ -1,40 +1,170 @@
 package closest
 
 import (
+	"math"
+
 	"github.com/go-gl/mathgl/mgl64"
 )
 
+// halfEdge is a directed edge of a face of the polytope in EPA.
+type halfEdge struct {
+	origin int // The index of the vertex in the simplex
+	twin   *halfEdge
+	next   *halfEdge
+	face   *face
+}
+
 type face struct {
-	indices [3]int
-	measure Measure
+	edge      *halfEdge
+	normal    mgl64.Vec3 // Outward unit normal
+	distance  float64    // Signed distance from the origin to the plane of the face
+	isDeleted bool
 }
 
-func newFace(simplex []*vertex, indices [3]int) (theFace *face) {
-	theFace = &face{
-		indices: indices,
-		measure: Measure{
-			ConvexHulls: [2][]*mgl64.Vec3{
-				{
-					&mgl64.Vec3{},
-				},
-			},
-		},
+// newFace makes the counterclockwise face of the vertices a, b and c without twins.
+// The face thinner than epsilon is degenerate, because its normal is made up of the rounding errors.
+func newFace(simplex []*vertex, a, b, c int, epsilon float64) (theFace *face) {
+	theFace = &face{}
+
+	edges := [3]*halfEdge{
+		{origin: a, face: theFace},
+		{origin: b, face: theFace},
+		{origin: c, face: theFace},
+	}
+	for i := 0; i < len(edges); i += 1 {
+		edges[i].next = edges[(i+1)%len(edges)]
+	}
+	theFace.edge = edges[0]
+
+	ab := simplex[b].coordinate.Sub(simplex[a].coordinate)
+	bc := simplex[c].coordinate.Sub(simplex[b].coordinate)
+	ca := simplex[a].coordinate.Sub(simplex[c].coordinate)
+	normal := ab.Cross(ca.Mul(-1.0))
+	// The height to the longest edge
+	if norm(normal) <= epsilon*math.Max(norm(ab), math.Max(norm(bc), norm(ca))) {
+		// Degenerated faces are never the closest.
+		theFace.distance = math.Inf(1)
+		return
 	}
+	theFace.normal = normalize(normal)
+	theFace.distance = theFace.normal.Dot(simplex[a].coordinate)
+
+	return
+}
 
-	for _, index := range indices {
-		newVertex := simplex[index].coordinate
-		theFace.measure.ConvexHulls[1] = append(theFace.measure.ConvexHulls[1],
-			&newVertex,
-		)
+// norm returns the length of v. Unlike Len of mgl64, it divides v by its largest component first,
+// so that the squares do not underflow for the cross products of the tiny coordinates.
+func norm(v mgl64.Vec3) float64 {
+	maxAbs := math.Max(math.Abs(v[0]), math.Max(math.Abs(v[1]), math.Abs(v[2])))
+	if maxAbs == 0.0 {
+		return 0.0
 	}
 
-	theFace.measure.gjk()
+	return maxAbs * v.Mul(1.0/maxAbs).Len()
+}
+
+// normalize returns the unit vector of v, or zero if v is zero, without underflowing like norm.
+func normalize(v mgl64.Vec3) mgl64.Vec3 {
+	maxAbs := math.Max(math.Abs(v[0]), math.Max(math.Abs(v[1]), math.Abs(v[2])))
+	if maxAbs == 0.0 {
+		return mgl64.Vec3{}
+	}
+
+	v = v.Mul(1.0 / maxAbs)
+	return v.Mul(1.0 / v.Len())
+}
+
+// connectTwins sets the twins of the half-edges of the closed faces.
+func connectTwins(faces []*face) {
+	edges := map[[2]int]*halfEdge{}
+	for _, face := range faces {
+		edge := face.edge
+		for i := 0; i < 3; i += 1 {
+			edges[[2]int{edge.origin, edge.next.origin}] = edge
+			edge = edge.next
+		}
+	}
+	for _, edge := range edges {
+		edge.twin = edges[[2]int{edge.next.origin, edge.origin}]
+	}
+}
+
+func (face *face) getIndices() [3]int {
+	return [3]int{
+		face.edge.origin,
+		face.edge.next.origin,
+		face.edge.next.next.origin,
+	}
+}
+
+// getBarycentricCoordinates returns the barycentric coordinates of the projection of the origin onto the face.
+func (face *face) getBarycentricCoordinates(simplex []*vertex) (coordinates [3]float64) {
+	indices := face.getIndices()
+	projection := face.normal.Mul(face.distance)
+
+	area := 0.0
+	for i := 0; i < 3; i += 1 {
+		b := simplex[indices[(i+1)%3]].coordinate.Sub(projection)
+		c := simplex[indices[(i+2)%3]].coordinate.Sub(projection)
+		coordinates[i] = face.normal.Dot(b.Cross(c))
+		area += coordinates[i]
+	}
+	if area == 0.0 {
+		return [3]float64{1.0, 0.0, 0.0}
+	}
+	for i := 0; i < 3; i += 1 {
+		coordinates[i] /= area
+	}
 
 	return
 }
 
-func (face face) getNormal(simplex []*vertex) mgl64.Vec3 {
-	return simplex[face.indices[1]].coordinate.Sub(simplex[face.indices[0]].coordinate).Cross(
-		simplex[face.indices[2]].coordinate.Sub(simplex[face.indices[0]].coordinate),
-	)
+// isVisibleFrom reports whether point is above the face.
+func (face *face) isVisibleFrom(point mgl64.Vec3) bool {
+	if math.IsInf(face.distance, 1) {
+		return true
+	}
+
+	return face.normal.Dot(point)-face.distance > 0.0
+}
+
+// faceHeap is a binary heap of faces keyed on the distance.
+type faceHeap []*face
+
+func (faces faceHeap) Len() int {
+	return len(faces)
+}
+
+func (faces faceHeap) Less(i int, j int) bool {
+	return faces[i].distance < faces[j].distance
+}
+
+func (faces faceHeap) Swap(i int, j int) {
+	faces[i], faces[j] = faces[j], faces[i]
+}
+
+func (faces *faceHeap) Push(theFace any) {
+	*faces = append(*faces, theFace.(*face))
+}
+
+func (faces *faceHeap) Pop() any {
+	last := (*faces)[len(*faces)-1]
+	*faces = (*faces)[:len(*faces)-1]
+	return last
+}
+
+// newPolytope returns the mesh of the faces not deleted.
+func newPolytope(simplex []*vertex, faces []*face) *Mesh {
+	mesh := &Mesh{}
+	for _, vertex := range simplex {
+		coordinate := vertex.coordinate
+		mesh.Vertices = append(mesh.Vertices, &coordinate)
+	}
+	for _, face := range faces {
+		if !face.isDeleted {
+			mesh.Triangles = append(mesh.Triangles, face.getIndices())
+		}
+	}
+
+	return mesh
 }
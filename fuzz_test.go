@@ -0,0 +1,190 @@
+package closest
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// maxFuzzCoordinate keeps the products of the coordinates finite.
+const maxFuzzCoordinate = 1e100
+
+// The convex hulls are compared with referenceDistance if they have at most maxReferencedCount vertices
+// and their scale is in between minReferencedScale and maxReferencedScale, so that referenceDistance is fast
+// and the products of the coordinates neither overflow nor underflow.
+const (
+	maxReferencedCount = 16
+	minReferencedScale = 1e-50
+	maxReferencedScale = 1e50
+)
+
+// decodeFuzzInput decodes data into the starting direction and two convex hulls.
+// The data are the direction, the number of the vertices of the first convex hull in a byte,
+// and the vertices, each of which is three little-endian float64 values.
+// It returns false if the data have too few vertices or coordinates not finite.
+func decodeFuzzInput(data []byte) (direction mgl64.Vec3, convexHulls [2][]*mgl64.Vec3, ok bool) {
+	const size = 3 * 8
+
+	readVec3 := func() (vector mgl64.Vec3) {
+		for i := 0; i < 3; i += 1 {
+			vector[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+		}
+		data = data[size:]
+		return
+	}
+	isValid := func(vector mgl64.Vec3) bool {
+		for i := 0; i < 3; i += 1 {
+			if !(math.Abs(vector[i]) <= maxFuzzCoordinate) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if len(data) < size+1 {
+		return
+	}
+	direction = readVec3()
+	if !isValid(direction) {
+		return
+	}
+
+	count := int(data[0])
+	data = data[1:]
+	for len(data) >= size {
+		vertex := readVec3()
+		if !isValid(vertex) {
+			return
+		}
+
+		i := 0
+		if len(convexHulls[0]) >= count {
+			i = 1
+		}
+		convexHulls[i] = append(convexHulls[i], &vertex)
+	}
+
+	ok = len(convexHulls[0]) != 0 && len(convexHulls[1]) != 0
+	return
+}
+
+// encodeFuzzInput is the inverse of decodeFuzzInput.
+func encodeFuzzInput(direction mgl64.Vec3, convexHulls [2][]*mgl64.Vec3) (data []byte) {
+	appendVec3 := func(vector mgl64.Vec3) {
+		for i := 0; i < 3; i += 1 {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(vector[i]))
+		}
+	}
+
+	appendVec3(direction)
+	data = append(data, byte(len(convexHulls[0])))
+	for _, convex := range convexHulls {
+		for _, vertex := range convex {
+			appendVec3(*vertex)
+		}
+	}
+
+	return
+}
+
+// checkFuzzInvariants measures data with measureDistance, and checks the invariants of the results.
+func checkFuzzInvariants(t *testing.T, data []byte, measureDistance func(*Measure), isNonnegative bool) {
+	direction, convexHulls, ok := decodeFuzzInput(data)
+	if !ok {
+		return
+	}
+
+	scale := 0.0
+	for _, convex := range convexHulls {
+		for _, vertex := range convex {
+			for i := 0; i < 3; i += 1 {
+				scale = math.Max(scale, math.Abs(vertex[i]))
+			}
+		}
+	}
+	tolerance := 1e-6 * scale
+
+	measures := [2]Measure{}
+	for i := range measures {
+		measures[i].ConvexHulls = convexHulls
+		measures[i].Direction = direction
+		if i == 1 {
+			// Swapped
+			measures[i].ConvexHulls = [2][]*mgl64.Vec3{
+				convexHulls[1],
+				convexHulls[0],
+			}
+			measures[i].Direction = direction.Mul(-1.0)
+		}
+		measureDistance(&measures[i])
+		measure := &measures[i]
+
+		if math.IsNaN(measure.Distance) {
+			t.Fatal("Distance is NaN.")
+		}
+		if isNonnegative && measure.Distance < 0.0 {
+			t.Fatal("Distance is negative: ", measure.Distance)
+		}
+		for j := 0; j < 3; j += 1 {
+			if math.IsNaN(measure.Direction[j]) {
+				t.Fatal("Direction is NaN: ", measure.Direction)
+			}
+		}
+		for j, point := range measure.Points {
+			lower, upper := getBounds(measure.ConvexHulls[j])
+			for k := 0; k < 3; k += 1 {
+				if !(lower[k]-tolerance <= point[k] && point[k] <= upper[k]+tolerance) {
+					t.Fatal("Points[", j, "] is out of the bounds: ", point, lower, upper)
+				}
+			}
+		}
+	}
+
+	if math.Abs(measures[0].Distance-measures[1].Distance) > tolerance {
+		t.Fatal("Distance is not symmetric: ", measures[0].Distance, measures[1].Distance)
+	}
+
+	if scale < minReferencedScale || scale > maxReferencedScale ||
+		len(uniqueVertices(convexHulls[0])) > maxReferencedCount || len(uniqueVertices(convexHulls[1])) > maxReferencedCount {
+		return
+	}
+	correctDistance := referenceDistance(convexHulls)
+	if isNonnegative {
+		correctDistance = math.Max(correctDistance, 0.0)
+	}
+	if math.Abs(measures[0].Distance-correctDistance) > tolerance {
+		t.Fatal("Distance: ", measures[0].Distance, " Reference: ", correctDistance, "\n", formatConvexHulls(convexHulls))
+	}
+}
+
+// addFuzzSeeds adds the degenerate cases to the seed corpus in testdata.
+func addFuzzSeeds(f *testing.F) {
+	for _, convexHulls := range [][2][]*mgl64.Vec3{
+		// Touching
+		{newCube(mgl64.Vec3{}, 1.0), newCube(mgl64.Vec3{2.0, 0.5, 0.25}, 1.0)},
+		// A point inside
+		{newCube(mgl64.Vec3{}, 1.0), {{0.25, 0.0, 0.0}}},
+		// Crossing segments
+		{{{0.0, 0.0, 0.0}, {1.0, 0.0, 0.0}}, {{0.5, -1.0, 0.0}, {0.5, 1.0, 0.0}}},
+		// Duplicated vertices
+		{{{1.0, 1.0, 1.0}, {1.0, 1.0, 1.0}}, {{1.0, 1.0, 1.0}}},
+	} {
+		f.Add(encodeFuzzInput(mgl64.Vec3{1.0, 0.0, 0.0}, convexHulls))
+	}
+}
+
+func FuzzMeasureDistance(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkFuzzInvariants(t, data, (*Measure).MeasureDistance, false)
+	})
+}
+
+func FuzzMeasureNonnegativeDistance(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		checkFuzzInvariants(t, data, (*Measure).MeasureNonnegativeDistance, true)
+	})
+}
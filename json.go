@@ -0,0 +1,147 @@
+package closest
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// measureJSON is the JSON encoding of Measure. The names of the fields must not be changed.
+type measureJSON struct {
+	// In
+	ConvexHulls       [2][]*mgl64.Vec3 `json:"convexHulls"`
+	Tolerance         float64          `json:"tolerance"`
+	WarmStartsSimplex bool             `json:"warmStartsSimplex"`
+	SimplexSolver     SimplexSolver    `json:"simplexSolver"`
+	Recenters         bool             `json:"recenters"`
+	KeepsPolytope     bool             `json:"keepsPolytope"`
+	KeepsHistory      bool             `json:"keepsHistory"`
+
+	// Out
+	Distance       float64       `json:"distance"`
+	Direction      mgl64.Vec3    `json:"direction"`
+	Points         [2]mgl64.Vec3 `json:"points"`
+	Ons            [2][]int      `json:"ons"` // Sorted, or null before measuring
+	IterationCount int           `json:"iterationCount"`
+	SeedCount      int           `json:"seedCount"`
+	Termination    Termination   `json:"termination"`
+	Polytope       *meshJSON     `json:"polytope,omitempty"`
+	History        [][][2]int    `json:"history,omitempty"`
+
+	// The last simplex, which the next measurement starts from
+	Simplex []vertexJSON `json:"simplex,omitempty"`
+}
+
+type meshJSON struct {
+	Vertices  []*mgl64.Vec3 `json:"vertices"`
+	Triangles [][3]int      `json:"triangles"`
+}
+
+type vertexJSON struct {
+	Indices               [2]int     `json:"indices"` // Of ConvexHulls[0] and ConvexHulls[1]
+	Coordinate            mgl64.Vec3 `json:"coordinate"`
+	BarycentricCoordinate float64    `json:"barycentricCoordinate"`
+}
+
+// MarshalJSON encodes the inputs, the outputs and the last simplex of measure.
+// Polytope, History and the last simplex are omitted if they are empty.
+// Marshaling measure before measuring makes the measurement reproducible,
+// because it starts from the last Direction and simplex.
+func (measure Measure) MarshalJSON() ([]byte, error) {
+	encoded := measureJSON{
+		ConvexHulls:       measure.ConvexHulls,
+		Tolerance:         measure.Tolerance,
+		WarmStartsSimplex: measure.WarmStartsSimplex,
+		SimplexSolver:     measure.SimplexSolver,
+		Recenters:         measure.Recenters,
+		KeepsPolytope:     measure.KeepsPolytope,
+		KeepsHistory:      measure.KeepsHistory,
+
+		Distance:       measure.Distance,
+		Direction:      measure.Direction,
+		Points:         measure.Points,
+		IterationCount: measure.IterationCount,
+		SeedCount:      measure.SeedCount,
+		Termination:    measure.Termination,
+		History:        measure.History,
+	}
+	if measure.Polytope != nil {
+		encoded.Polytope = &meshJSON{
+			Vertices:  measure.Polytope.Vertices,
+			Triangles: measure.Polytope.Triangles,
+		}
+	}
+	for i, on := range measure.Ons {
+		if on == nil {
+			continue
+		}
+
+		encoded.Ons[i] = make([]int, 0, len(on))
+		for index := range on {
+			encoded.Ons[i] = append(encoded.Ons[i], index)
+		}
+		sort.Ints(encoded.Ons[i])
+	}
+	for _, vertex := range measure.simplex {
+		encoded.Simplex = append(encoded.Simplex, vertexJSON{
+			Indices:               vertex.indices,
+			Coordinate:            vertex.coordinate,
+			BarycentricCoordinate: vertex.barycentricCoordinate,
+		})
+	}
+
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes the encoding of MarshalJSON into measure.
+func (measure *Measure) UnmarshalJSON(data []byte) error {
+	decoded := measureJSON{}
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*measure = Measure{
+		ConvexHulls:       decoded.ConvexHulls,
+		Tolerance:         decoded.Tolerance,
+		WarmStartsSimplex: decoded.WarmStartsSimplex,
+		SimplexSolver:     decoded.SimplexSolver,
+		Recenters:         decoded.Recenters,
+		KeepsPolytope:     decoded.KeepsPolytope,
+		KeepsHistory:      decoded.KeepsHistory,
+
+		Distance:       decoded.Distance,
+		Direction:      decoded.Direction,
+		Points:         decoded.Points,
+		IterationCount: decoded.IterationCount,
+		SeedCount:      decoded.SeedCount,
+		Termination:    decoded.Termination,
+		History:        decoded.History,
+	}
+	if decoded.Polytope != nil {
+		measure.Polytope = &Mesh{
+			Vertices:  decoded.Polytope.Vertices,
+			Triangles: decoded.Polytope.Triangles,
+		}
+	}
+	for i, indices := range decoded.Ons {
+		if indices == nil {
+			continue
+		}
+
+		measure.Ons[i] = map[int]struct{}{}
+		for _, index := range indices {
+			measure.Ons[i][index] = struct{}{}
+		}
+	}
+	for _, decodedVertex := range decoded.Simplex {
+		measure.simplex = append(measure.simplex, &vertex{
+			indices:               decodedVertex.Indices,
+			coordinate:            decodedVertex.Coordinate,
+			barycentricCoordinate: decodedVertex.BarycentricCoordinate,
+		})
+	}
+
+	return nil
+}
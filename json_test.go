@@ -0,0 +1,145 @@
+package closest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMeasure_MarshalJSON(t *testing.T) {
+	measure := Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			{{0.0, 0.0, 0.0}, {1.0, 0.0, 0.0}},
+			{{3.0, 0.0, 0.0}, {3.0, 1.0, 0.0}},
+		},
+		SimplexSolver: SignedVolumes,
+	}
+	// Before measuring
+	data, err := json.Marshal(measure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correct := `{"convexHulls":[[[0,0,0],[1,0,0]],[[3,0,0],[3,1,0]]],"tolerance":0,"warmStartsSimplex":false,"simplexSolver":"SignedVolumes","recenters":false,"keepsPolytope":false,"keepsHistory":false,` +
+		`"distance":0,"direction":[0,0,0],"points":[[0,0,0],[0,0,0]],"ons":[null,null],"iterationCount":0,"seedCount":0,"termination":"Unspecified"}`
+	if difference := cmp.Diff(string(data), correct); difference != "" {
+		t.Error(difference)
+	}
+
+	measure.Direction = mgl64.Vec3{1.0, 0.0, 0.0}
+	measure.MeasureDistance()
+	data, err = json.Marshal(&measure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	correct = `{"convexHulls":[[[0,0,0],[1,0,0]],[[3,0,0],[3,1,0]]],"tolerance":0,"warmStartsSimplex":false,"simplexSolver":"SignedVolumes","recenters":false,"keepsPolytope":false,"keepsHistory":false,` +
+		`"distance":2,"direction":[2,0,0],"points":[[1,0,0],[3,0,0]],"ons":[[1],[0]],"iterationCount":2,"seedCount":0,"termination":"Converged",` +
+		`"simplex":[{"indices":[1,0],"coordinate":[2,0,0],"barycentricCoordinate":1}]}`
+	if difference := cmp.Diff(string(data), correct); difference != "" {
+		t.Error(difference)
+	}
+}
+
+func TestMeasure_UnmarshalJSON(t *testing.T) {
+	box := newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 1.0, 1.0})
+	other := newBox(mgl64.Vec3{2.0, 0.2, 0.1}, mgl64.Vec3{3.0, 1.2, 1.1})
+	measure := Measure{
+		ConvexHulls:       [2][]*mgl64.Vec3{box, other},
+		WarmStartsSimplex: true,
+	}
+	measure.MeasureDistance()
+
+	// Capture the case before measuring the next frame.
+	for _, vertex := range other {
+		*vertex = vertex.Add(mgl64.Vec3{-0.1, 0.01, 0.0})
+	}
+	data, err := json.Marshal(measure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	measure.MeasureDistance()
+
+	// Reproduce it.
+	reproduced := Measure{}
+	err = json.Unmarshal(data, &reproduced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(reproduced.ConvexHulls, measure.ConvexHulls) || len(reproduced.simplex) == 0 {
+		t.Fatal(string(data))
+	}
+	reData, err := json.Marshal(reproduced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if difference := cmp.Diff(string(reData), string(data)); difference != "" {
+		t.Error("Not round trip: ", difference)
+	}
+
+	reproduced.MeasureDistance()
+	if reproduced.Distance != measure.Distance || reproduced.Direction != measure.Direction ||
+		reproduced.IterationCount != measure.IterationCount || reproduced.SeedCount != measure.SeedCount ||
+		reproduced.SeedCount == 0 || !cmp.Equal(reproduced.Ons, measure.Ons) {
+		t.Error("Not reproduced: ", reproduced, measure)
+	}
+
+	err = json.Unmarshal([]byte(`{"termination":"Unknown"}`), &reproduced)
+	if err == nil {
+		t.Error("An unknown termination is accepted.")
+	}
+}
+
+func TestMeasure_UnmarshalJSON_Polytope(t *testing.T) {
+	measure := Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 1.0, 1.0}),
+			newBox(mgl64.Vec3{0.5, 0.25, 0.125}, mgl64.Vec3{1.5, 1.25, 1.125}),
+		},
+		KeepsPolytope: true,
+	}
+	measure.MeasureDistance()
+	if measure.Polytope == nil {
+		t.Fatal("No polytope: ", measure.Termination)
+	}
+
+	data, err := json.Marshal(measure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reproduced := Measure{}
+	err = json.Unmarshal(data, &reproduced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reproduced.KeepsPolytope || !cmp.Equal(reproduced.Polytope, measure.Polytope) {
+		t.Error("Not round trip: ", string(data))
+	}
+}
+
+func TestMeasure_UnmarshalJSON_History(t *testing.T) {
+	measure := Measure{
+		ConvexHulls: [2][]*mgl64.Vec3{
+			newBox(mgl64.Vec3{0.0, 0.0, 0.0}, mgl64.Vec3{1.0, 1.0, 1.0}),
+			newBox(mgl64.Vec3{2.0, 0.25, 0.125}, mgl64.Vec3{3.0, 1.25, 1.125}),
+		},
+		KeepsHistory: true,
+	}
+	measure.MeasureDistance()
+	if len(measure.History) == 0 {
+		t.Fatal("No history: ", measure.Termination)
+	}
+
+	data, err := json.Marshal(measure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reproduced := Measure{}
+	err = json.Unmarshal(data, &reproduced)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reproduced.KeepsHistory || !cmp.Equal(reproduced.History, measure.History) {
+		t.Error("Not round trip: ", string(data))
+	}
+}
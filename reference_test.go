@@ -0,0 +1,472 @@
+package closest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// referenceDistance measures the distance or the depth by brute force over the features of the convex hulls,
+// without the code under test. It is slow but simple enough to be trusted.
+func referenceDistance(convexHulls [2][]*mgl64.Vec3) float64 {
+	vertices := [2][]mgl64.Vec3{
+		uniqueVertices(convexHulls[0]),
+		uniqueVertices(convexHulls[1]),
+	}
+
+	scale := 0.0
+	for _, convex := range vertices {
+		for _, vertex := range convex {
+			for i := 0; i < 3; i += 1 {
+				scale = math.Max(scale, math.Abs(vertex[i]))
+			}
+		}
+	}
+
+	// The rounding errors of the crossings are relative to the coordinates.
+	distance := referenceSeparation(vertices)
+	if distance > 1e-12*scale {
+		return distance
+	}
+
+	return -referenceDepth(vertices)
+}
+
+// referenceSeparation returns the distance between the convex hulls of vertices, or 0 if they intersect.
+// The closest points of the separated convex hulls are on a pair of a vertex and a triangle or of two edges,
+// and the intersecting convex hulls have a vertex in a tetrahedron or an edge crossing a triangle of the other.
+func referenceSeparation(vertices [2][]mgl64.Vec3) float64 {
+	distance := math.Inf(1)
+	for i := 0; i < 2; i += 1 {
+		this, other := vertices[i], vertices[1-i]
+		for _, p := range this {
+			distance = math.Min(distance, distanceToConvexHull(p, other))
+		}
+
+		forEachSegment(this, func(p0, p1 mgl64.Vec3) {
+			forEachTriangle(other, func(a, b, c mgl64.Vec3) {
+				normal := b.Sub(a).Cross(c.Sub(a))
+				d0 := normal.Dot(p0.Sub(a))
+				d1 := normal.Dot(p1.Sub(a))
+				if d0*d1 < 0.0 {
+					crossing := p0.Add(p1.Sub(p0).Mul(d0 / (d0 - d1)))
+					distance = math.Min(distance, distanceToTriangle(crossing, a, b, c))
+				}
+			})
+		})
+	}
+
+	forEachSegment(vertices[0], func(p0, p1 mgl64.Vec3) {
+		forEachSegment(vertices[1], func(q0, q1 mgl64.Vec3) {
+			distance = math.Min(distance, distanceBetweenSegments(p0, p1, q0, q1))
+		})
+	})
+
+	return distance
+}
+
+// referenceDepth returns the depth of the intersecting convex hulls of vertices.
+// The depth is the minimum of the support function of the Minkowski difference over the unit directions,
+// and it is at the normal of a face of either convex hull or of the cross product of their edges.
+// The axes and the perpendiculars of the edges cover the flat Minkowski differences, whose depth is 0.
+func referenceDepth(vertices [2][]mgl64.Vec3) float64 {
+	directions := []mgl64.Vec3{
+		{1.0, 0.0, 0.0},
+		{0.0, 1.0, 0.0},
+		{0.0, 0.0, 1.0},
+	}
+	edges := [2][]mgl64.Vec3{}
+	for i := 0; i < 2; i += 1 {
+		forEachTriangle(vertices[i], func(a, b, c mgl64.Vec3) {
+			directions = append(directions, b.Sub(a).Cross(c.Sub(a)))
+		})
+		forEachSegment(vertices[i], func(p0, p1 mgl64.Vec3) {
+			edges[i] = append(edges[i], p1.Sub(p0))
+			for axis := 0; axis < 3; axis += 1 {
+				directions = append(directions, p1.Sub(p0).Cross(directions[axis]))
+			}
+		})
+	}
+	for _, edge0 := range edges[0] {
+		for _, edge1 := range edges[1] {
+			directions = append(directions, edge0.Cross(edge1))
+		}
+	}
+
+	depth := math.Inf(1)
+	for _, direction := range directions {
+		if direction.Len() == 0.0 {
+			continue
+		}
+		direction = direction.Normalize()
+
+		for _, sign := range []float64{1.0, -1.0} {
+			// The support of the Minkowski difference, vertices[1] - vertices[0], along sign * direction
+			max1 := math.Inf(-1)
+			for _, b := range vertices[1] {
+				max1 = math.Max(max1, sign*direction.Dot(b))
+			}
+			min0 := math.Inf(1)
+			for _, a := range vertices[0] {
+				min0 = math.Min(min0, sign*direction.Dot(a))
+			}
+			depth = math.Min(depth, max1-min0)
+		}
+	}
+
+	return depth
+}
+
+// distanceToConvexHull returns the distance from p to the convex hull of vertices.
+// The point in the convex hull is in a tetrahedron of the vertices, and the point out of it is the closest
+// to a triangle, an edge or a vertex.
+func distanceToConvexHull(p mgl64.Vec3, vertices []mgl64.Vec3) float64 {
+	distance := math.Inf(1)
+	for _, vertex := range vertices {
+		distance = math.Min(distance, p.Sub(vertex).Len())
+	}
+	forEachSegment(vertices, func(a, b mgl64.Vec3) {
+		distance = math.Min(distance, distanceToSegment(p, a, b))
+	})
+	forEachTriangle(vertices, func(a, b, c mgl64.Vec3) {
+		distance = math.Min(distance, distanceToTriangle(p, a, b, c))
+	})
+
+	for i := 0; i < len(vertices); i += 1 {
+		for j := i + 1; j < len(vertices); j += 1 {
+			for k := j + 1; k < len(vertices); k += 1 {
+				for l := k + 1; l < len(vertices); l += 1 {
+					distance = math.Min(distance, distanceInTetrahedron(p, vertices[i], vertices[j], vertices[k], vertices[l]))
+				}
+			}
+		}
+	}
+
+	return distance
+}
+
+func distanceToSegment(p, a, b mgl64.Vec3) float64 {
+	ab := b.Sub(a)
+	if ab.LenSqr() == 0.0 {
+		return p.Sub(a).Len()
+	}
+
+	t := math.Max(0.0, math.Min(1.0, p.Sub(a).Dot(ab)/ab.LenSqr()))
+	return p.Sub(a.Add(ab.Mul(t))).Len()
+}
+
+// distanceToTriangle returns the distance from p to the triangle abc, whose closest point is
+// the projection of p onto the plane if it is in the triangle, or on an edge otherwise.
+func distanceToTriangle(p, a, b, c mgl64.Vec3) float64 {
+	distance := math.Min(distanceToSegment(p, a, b), math.Min(distanceToSegment(p, b, c), distanceToSegment(p, c, a)))
+
+	normal := b.Sub(a).Cross(c.Sub(a))
+	coordinates := []float64{
+		c.Sub(b).Cross(p.Sub(b)).Dot(normal),
+		a.Sub(c).Cross(p.Sub(c)).Dot(normal),
+		b.Sub(a).Cross(p.Sub(a)).Dot(normal),
+	}
+	return math.Min(distance, distanceToCombination(p, []mgl64.Vec3{a, b, c}, coordinates))
+}
+
+// distanceBetweenSegments returns the distance between the segments p0p1 and q0q1, whose closest points are
+// at an end of either segment, or at the closest points of their lines in both of them.
+func distanceBetweenSegments(p0, p1, q0, q1 mgl64.Vec3) float64 {
+	distance := math.Min(
+		math.Min(distanceToSegment(p0, q0, q1), distanceToSegment(p1, q0, q1)),
+		math.Min(distanceToSegment(q0, p0, p1), distanceToSegment(q1, p0, p1)),
+	)
+
+	u := p1.Sub(p0)
+	v := q1.Sub(q0)
+	w := p0.Sub(q0)
+	a := u.Dot(u)
+	b := u.Dot(v)
+	c := v.Dot(v)
+	denominator := a*c - b*b
+	if denominator <= 0.0 {
+		// Parallel
+		return distance
+	}
+	s := (b*v.Dot(w) - c*u.Dot(w)) / denominator
+	t := (a*v.Dot(w) - b*u.Dot(w)) / denominator
+	if 0.0 <= s && s <= 1.0 && 0.0 <= t && t <= 1.0 {
+		distance = math.Min(distance, p0.Add(u.Mul(s)).Sub(q0.Add(v.Mul(t))).Len())
+	}
+
+	return distance
+}
+
+// distanceInTetrahedron returns the distance from p to itself in the tetrahedron abcd, or +Inf if it is out of it.
+func distanceInTetrahedron(p, a, b, c, d mgl64.Vec3) float64 {
+	volume := func(a, b, c, d mgl64.Vec3) float64 {
+		return b.Sub(a).Cross(c.Sub(a)).Dot(d.Sub(a))
+	}
+
+	coordinates := []float64{
+		volume(p, b, c, d),
+		volume(a, p, c, d),
+		volume(a, b, p, d),
+		volume(a, b, c, p),
+	}
+	if volume(a, b, c, d) < 0.0 {
+		for i := range coordinates {
+			coordinates[i] = -coordinates[i]
+		}
+	}
+	return distanceToCombination(p, []mgl64.Vec3{a, b, c, d}, coordinates)
+}
+
+// distanceToCombination returns the distance from p to the convex combination of vertices
+// with the unnormalized barycentric coordinates, or +Inf if they are not all nonnegative.
+// The combination is in the convex hull of vertices even if the coordinates are rounded,
+// so the distance is never less than the one to the convex hull.
+func distanceToCombination(p mgl64.Vec3, vertices []mgl64.Vec3, coordinates []float64) float64 {
+	sum := 0.0
+	for _, coordinate := range coordinates {
+		if !(coordinate >= 0.0) {
+			return math.Inf(1)
+		}
+		sum += coordinate
+	}
+	if sum == 0.0 {
+		return math.Inf(1)
+	}
+
+	combination := mgl64.Vec3{}
+	for i, vertex := range vertices {
+		combination = combination.Add(vertex.Mul(coordinates[i] / sum))
+	}
+	return p.Sub(combination).Len()
+}
+
+func forEachSegment(vertices []mgl64.Vec3, do func(a, b mgl64.Vec3)) {
+	for i := 0; i < len(vertices); i += 1 {
+		for j := i + 1; j < len(vertices); j += 1 {
+			do(vertices[i], vertices[j])
+		}
+	}
+}
+
+func forEachTriangle(vertices []mgl64.Vec3, do func(a, b, c mgl64.Vec3)) {
+	for i := 0; i < len(vertices); i += 1 {
+		for j := i + 1; j < len(vertices); j += 1 {
+			for k := j + 1; k < len(vertices); k += 1 {
+				do(vertices[i], vertices[j], vertices[k])
+			}
+		}
+	}
+}
+
+func uniqueVertices(convex []*mgl64.Vec3) []mgl64.Vec3 {
+	vertices := []mgl64.Vec3{}
+	isKnown := map[mgl64.Vec3]bool{}
+	for _, vertex := range convex {
+		if !isKnown[*vertex] {
+			isKnown[*vertex] = true
+			vertices = append(vertices, *vertex)
+		}
+	}
+
+	return vertices
+}
+
+// checkMeasure returns the description of the first difference from correctDistance of referenceDistance,
+// or "" if there is none.
+func checkMeasure(convexHulls [2][]*mgl64.Vec3, correctDistance float64, simplexSolver SimplexSolver, isNonnegative bool) string {
+	const tolerance = 1e-9
+
+	measure := Measure{
+		ConvexHulls:   convexHulls,
+		SimplexSolver: simplexSolver,
+	}
+	if isNonnegative {
+		measure.MeasureNonnegativeDistance()
+		correctDistance = math.Max(correctDistance, 0.0)
+	} else {
+		measure.MeasureDistance()
+	}
+
+	if !(math.Abs(measure.Distance-correctDistance) <= tolerance) {
+		return fmt.Sprint("Distance: ", measure.Distance, " Reference: ", correctDistance)
+	}
+	if math.Abs(measure.Direction.Len()-math.Abs(measure.Distance)) > tolerance {
+		return fmt.Sprint("Direction: ", measure.Direction, " Distance: ", measure.Distance)
+	}
+	if measure.Points[1].Sub(measure.Points[0]).Sub(measure.Direction).Len() > tolerance {
+		return fmt.Sprint("Points: ", measure.Points, " Direction: ", measure.Direction)
+	}
+	for i, convex := range convexHulls {
+		if distanceToConvexHull(measure.Points[i], uniqueVertices(convex)) > tolerance {
+			return fmt.Sprint("Points[", i, "] is out of the convex hull: ", measure.Points[i])
+		}
+	}
+
+	return ""
+}
+
+// shrink removes the vertices and rounds the coordinates of convexHulls as long as isFailing.
+func shrink(convexHulls [2][]*mgl64.Vec3, isFailing func([2][]*mgl64.Vec3) bool) [2][]*mgl64.Vec3 {
+	for isShrunk := true; isShrunk; {
+		isShrunk = false
+
+		for i := range convexHulls {
+			for j := 0; j < len(convexHulls[i]) && len(convexHulls[i]) > 1; j += 1 {
+				candidate := convexHulls
+				candidate[i] = append(append([]*mgl64.Vec3{}, convexHulls[i][:j]...), convexHulls[i][j+1:]...)
+				if isFailing(candidate) {
+					convexHulls = candidate
+					isShrunk = true
+					j -= 1
+				}
+			}
+		}
+
+		for i := range convexHulls {
+			for j := range convexHulls[i] {
+				for k := 0; k < 3; k += 1 {
+					for digits := 0; digits <= 6; digits += 1 {
+						power := math.Pow(10.0, float64(digits))
+						rounded := math.Round(convexHulls[i][j][k]*power) / power
+						if rounded == convexHulls[i][j][k] {
+							break
+						}
+
+						candidate := convexHulls
+						candidate[i] = append([]*mgl64.Vec3{}, convexHulls[i]...)
+						vertex := *convexHulls[i][j]
+						vertex[k] = rounded
+						candidate[i][j] = &vertex
+						if isFailing(candidate) {
+							convexHulls = candidate
+							isShrunk = true
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return convexHulls
+}
+
+// formatConvexHulls formats convexHulls as Go literals to paste into a test.
+func formatConvexHulls(convexHulls [2][]*mgl64.Vec3) string {
+	builder := strings.Builder{}
+	for _, convex := range convexHulls {
+		builder.WriteString("[]*mgl64.Vec3{\n")
+		for _, vertex := range convex {
+			builder.WriteString("\t{")
+			for k := 0; k < 3; k += 1 {
+				if k != 0 {
+					builder.WriteString(", ")
+				}
+				builder.WriteString(strconv.FormatFloat(vertex[k], 'g', -1, 64))
+			}
+			builder.WriteString("},\n")
+		}
+		builder.WriteString("},\n")
+	}
+
+	return builder.String()
+}
+
+// newRandomConvexHull makes a convex hull of various shapes, including degenerate ones.
+func newRandomConvexHull(random *rand.Rand) (convex []*mgl64.Vec3) {
+	center := mgl64.Vec3{random.Float64(), random.Float64(), random.Float64()}
+	size := mgl64.Vec3{random.Float64(), random.Float64(), random.Float64()}
+	switch random.Intn(4) {
+	case 0:
+		// Flat
+		size[random.Intn(3)] = 0.0
+	case 1:
+		// Thin
+		size[random.Intn(3)] *= 1e-3
+	}
+
+	for i := random.Intn(12) + 1; i > 0; i -= 1 {
+		vertex := mgl64.Vec3{
+			center[0] + size[0]*(random.Float64()-0.5),
+			center[1] + size[1]*(random.Float64()-0.5),
+			center[2] + size[2]*(random.Float64()-0.5),
+		}
+		convex = append(convex, &vertex)
+		if random.Intn(8) == 0 {
+			// Duplicated
+			convex = append(convex, &vertex)
+		}
+	}
+
+	return
+}
+
+func TestMeasureDifferentially(t *testing.T) {
+	count := 2000
+	if testing.Short() {
+		count = 200
+	}
+
+	for seed := int64(0); seed < int64(count); seed += 1 {
+		random := rand.New(rand.NewSource(seed))
+		convexHulls := [2][]*mgl64.Vec3{
+			newRandomConvexHull(random),
+			newRandomConvexHull(random),
+		}
+		correctDistance := referenceDistance(convexHulls)
+
+		for _, simplexSolver := range []SimplexSolver{VoronoiRegions, SignedVolumes} {
+			for _, isNonnegative := range []bool{false, true} {
+				difference := checkMeasure(convexHulls, correctDistance, simplexSolver, isNonnegative)
+				if difference == "" {
+					continue
+				}
+
+				isFailing := func(candidate [2][]*mgl64.Vec3) bool {
+					return checkMeasure(candidate, referenceDistance(candidate), simplexSolver, isNonnegative) != ""
+				}
+				shrunk := shrink(convexHulls, isFailing)
+				t.Error(
+					"SimplexSolver: ", simplexSolver, " Nonnegative: ", isNonnegative, " Seed: ", seed, "\n",
+					difference, "\n",
+					"Shrunk: ", checkMeasure(shrunk, referenceDistance(shrunk), simplexSolver, isNonnegative), "\n",
+					formatConvexHulls(shrunk),
+				)
+			}
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	// Failing if the first convex hull has a vertex with the x coordinate greater than 0.5.
+	shrunk := shrink(
+		[2][]*mgl64.Vec3{
+			{
+				{0.1, 0.2, 0.3},
+				{0.7123, 0.8, 0.9},
+				{0.6, 0.1, 0.1},
+			},
+			{
+				{1.0, 2.0, 3.0},
+				{4.0, 5.0, 6.0},
+			},
+		},
+		func(candidate [2][]*mgl64.Vec3) bool {
+			for _, vertex := range candidate[0] {
+				if vertex[0] > 0.5 {
+					return true
+				}
+			}
+			return false
+		},
+	)
+
+	if len(shrunk[0]) != 1 || len(shrunk[1]) != 1 || *shrunk[0][0] != (mgl64.Vec3{1.0, 0.0, 0.0}) {
+		t.Error(formatConvexHulls(shrunk))
+	}
+}
@@ -0,0 +1,88 @@
+package closest
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// closestPointOnSegment returns the closest point to p on the segment ab.
+func closestPointOnSegment(p, a, b mgl64.Vec3) mgl64.Vec3 {
+	ab := b.Sub(a)
+	denominator := ab.LenSqr()
+	if denominator == 0.0 {
+		return a
+	}
+
+	t := p.Sub(a).Dot(ab) / denominator
+	if t <= 0.0 {
+		return a
+	}
+	if t >= 1.0 {
+		return b
+	}
+	return a.Add(ab.Mul(t))
+}
+
+// closestPointOnTriangle returns the closest point to p on the triangle abc.
+// This follows the Voronoi regions of the triangle in Real-Time Collision Detection by Christer Ericson.
+func closestPointOnTriangle(p, a, b, c mgl64.Vec3) mgl64.Vec3 {
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	ap := p.Sub(a)
+
+	d1 := ab.Dot(ap)
+	d2 := ac.Dot(ap)
+	if d1 <= 0.0 && d2 <= 0.0 {
+		// Region A
+		return a
+	}
+
+	bp := p.Sub(b)
+	d3 := ab.Dot(bp)
+	d4 := ac.Dot(bp)
+	if d3 >= 0.0 && d4 <= d3 {
+		// Region B
+		return b
+	}
+
+	vc := d1*d4 - d3*d2
+	if vc <= 0.0 && d1 >= 0.0 && d3 <= 0.0 {
+		// Region AB
+		return a.Add(ab.Mul(d1 / (d1 - d3)))
+	}
+
+	cp := p.Sub(c)
+	d5 := ab.Dot(cp)
+	d6 := ac.Dot(cp)
+	if d6 >= 0.0 && d5 <= d6 {
+		// Region C
+		return c
+	}
+
+	vb := d5*d2 - d1*d6
+	if vb <= 0.0 && d2 >= 0.0 && d6 <= 0.0 {
+		// Region AC
+		return a.Add(ac.Mul(d2 / (d2 - d6)))
+	}
+
+	va := d3*d6 - d5*d4
+	if va <= 0.0 && d4-d3 >= 0.0 && d5-d6 >= 0.0 {
+		// Region BC
+		return b.Add(c.Sub(b).Mul((d4 - d3) / ((d4 - d3) + (d5 - d6))))
+	}
+
+	// Region ABC
+	denominator := va + vb + vc
+	if denominator == 0.0 {
+		// The triangle is degenerated to a segment.
+		closest := closestPointOnSegment(p, a, b)
+		for _, candidate := range []mgl64.Vec3{closestPointOnSegment(p, b, c), closestPointOnSegment(p, c, a)} {
+			if candidate.Sub(p).LenSqr() < closest.Sub(p).LenSqr() {
+				closest = candidate
+			}
+		}
+		return closest
+	}
+	v := vb / denominator
+	w := vc / denominator
+	return a.Add(ab.Mul(v)).Add(ac.Mul(w))
+}
@@ -39,3 +39,13 @@ func getIndexOfMaxDotWithDirection(convex []*mgl64.Vec3, direction mgl64.Vec3) (
 
 	return
 }
+
+func copySimplex(simplex []*vertex) []*vertex {
+	copied := make([]*vertex, len(simplex))
+	for i, vertex := range simplex {
+		newVertex := *vertex
+		copied[i] = &newVertex
+	}
+
+	return copied
+}